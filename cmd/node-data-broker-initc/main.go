@@ -30,6 +30,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/pkg/providers/aws"
+	"github.com/NVIDIA/topograph/pkg/providers/azure"
 	"github.com/NVIDIA/topograph/pkg/providers/crusoe"
 	"github.com/NVIDIA/topograph/pkg/providers/dra"
 	"github.com/NVIDIA/topograph/pkg/providers/gcp"
@@ -100,6 +101,8 @@ func getAnnotations(ctx context.Context, client *kubernetes.Clientset, config *r
 	switch provider {
 	case aws.NAME:
 		return aws.GetNodeAnnotations(ctx)
+	case azure.NAME:
+		return azure.GetNodeAnnotations(ctx, client, config, nodeName)
 	case crusoe.NAME:
 		return crusoe.GetNodeAnnotations(ctx, nodeName)
 	case gcp.NAME:
@@ -107,7 +110,7 @@ func getAnnotations(ctx context.Context, client *kubernetes.Clientset, config *r
 	case oci.NAME:
 		return oci.GetNodeAnnotations(ctx)
 	case nebius.NAME:
-		return nebius.GetNodeAnnotations(ctx)
+		return nebius.GetNodeAnnotations(ctx, client, config, nodeName)
 	case dra.NAME:
 		return dra.GetNodeAnnotations(ctx, nodeName)
 	case infiniband.NAME_K8S:
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+)
+
+const (
+	// PluginVersion is the version of the plugin request and response schema
+	PluginVersion = "v1"
+
+	DefaultPluginTimeout = time.Minute
+
+	// maxStderr is the maximal length of the plugin stderr in error messages
+	maxStderr = 4096
+	// waitDelay bounds the wait for the plugin output after the plugin is killed,
+	// e.g. if its child processes keep stdout open
+	waitDelay = 5 * time.Second
+)
+
+// pluginExitCodes maps the plugin exit codes to the HTTP status codes;
+// the other non-zero exit codes map to http.StatusBadGateway
+var pluginExitCodes = map[int]int{
+	2: http.StatusBadRequest,
+	3: http.StatusUnauthorized,
+	4: http.StatusNotFound,
+	5: http.StatusServiceUnavailable,
+}
+
+// Plugin is an external binary registered as a provider or an engine.
+// The plugin receives the JSON request on stdin and writes the response to stdout.
+type Plugin struct {
+	// Name is the name of the provider or the engine
+	Name string `yaml:"name"`
+	// Command is the path of the plugin binary
+	Command string `yaml:"command"`
+	// Args (optional) lists the command arguments
+	Args []string `yaml:"args,omitempty"`
+	// Env (optional) specifies the environment variables of the plugin
+	Env map[string]string `yaml:"env,omitempty"`
+	// Timeout (optional) specifies the maximal duration of the plugin run
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+func (p *Plugin) Validate() error {
+	if len(p.Name) == 0 {
+		return fmt.Errorf("missing plugin name")
+	}
+	if len(p.Command) == 0 {
+		return fmt.Errorf("missing command of plugin %s", p.Name)
+	}
+	if p.Timeout < 0 {
+		return fmt.Errorf("invalid timeout %s of plugin %s", p.Timeout, p.Name)
+	}
+	if p.Timeout == 0 {
+		p.Timeout = DefaultPluginTimeout
+	}
+	return nil
+}
+
+// Run runs the plugin with the JSON-encoded request on stdin, and returns its stdout.
+// Non-zero exit codes are mapped to HTTP status codes, with stderr as the error message.
+func (p *Plugin) Run(ctx context.Context, request any) ([]byte, *httperr.Error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, fmt.Sprintf("failed to encode request of plugin %s: %v", p.Name, err))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	// the request may carry credentials, so it is passed on stdin and never on the command line
+	klog.V(2).Infof("Execute plugin %s: %s", p.Name, strings.Join(append([]string{p.Command}, p.Args...), " "))
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.WaitDelay = waitDelay
+	cmd.Env = os.Environ()
+	for k, v := range p.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err == nil {
+		return stdout.Bytes(), nil
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, httperr.NewError(http.StatusGatewayTimeout, fmt.Sprintf("plugin %s timed out after %s", p.Name, p.Timeout))
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil, httperr.NewError(http.StatusInternalServerError, fmt.Sprintf("failed to run plugin %s: %v", p.Name, err))
+	}
+
+	code, ok := pluginExitCodes[exitErr.ExitCode()]
+	if !ok {
+		code = http.StatusBadGateway
+	}
+
+	msg := strings.TrimSpace(stderr.String())
+	if len(msg) > maxStderr {
+		msg = msg[len(msg)-maxStderr:]
+	}
+	klog.Errorf("Plugin %s failed with exit code %d: %s", p.Name, exitErr.ExitCode(), msg)
+
+	return nil, httperr.NewError(code, fmt.Sprintf("plugin %s failed with exit code %d: %s", p.Name, exitErr.ExitCode(), msg))
+}
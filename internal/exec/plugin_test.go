@@ -0,0 +1,91 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package exec
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+)
+
+func TestPluginRun(t *testing.T) {
+	testCases := []struct {
+		name   string
+		script string
+		cmd    string
+		out    string
+		err    *httperr.Error
+	}{
+		{
+			name:   "Case 1: request on stdin",
+			script: `echo "$PLUGIN_ENV"; cat`,
+			out:    "value\n{\"key\":\"secret\"}",
+		},
+		{
+			name:   "Case 2: bad request",
+			script: `echo "invalid params" >&2; exit 2`,
+			err:    httperr.NewError(http.StatusBadRequest, "plugin test failed with exit code 2: invalid params"),
+		},
+		{
+			name:   "Case 3: unavailable",
+			script: `echo "inventory unavailable" >&2; exit 5`,
+			err:    httperr.NewError(http.StatusServiceUnavailable, "plugin test failed with exit code 5: inventory unavailable"),
+		},
+		{
+			name:   "Case 4: other exit code",
+			script: `exit 1`,
+			err:    httperr.NewError(http.StatusBadGateway, "plugin test failed with exit code 1: "),
+		},
+		{
+			name:   "Case 5: timeout",
+			script: `exec sleep 5`,
+			err:    httperr.NewError(http.StatusGatewayTimeout, "plugin test timed out after 200ms"),
+		},
+		{
+			name: "Case 6: missing binary",
+			cmd:  "/nonexistent/plugin",
+			err:  httperr.NewError(http.StatusInternalServerError, "failed to run plugin test: fork/exec /nonexistent/plugin: no such file or directory"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plugin := &Plugin{
+				Name:    "test",
+				Command: "/bin/sh",
+				Args:    []string{"-c", tc.script},
+				Env:     map[string]string{"PLUGIN_ENV": "value"},
+				Timeout: 200 * time.Millisecond,
+			}
+			if len(tc.cmd) != 0 {
+				plugin.Command, plugin.Args = tc.cmd, nil
+			}
+			require.NoError(t, plugin.Validate())
+
+			out, err := plugin.Run(context.TODO(), map[string]string{"key": "secret"})
+			if tc.err != nil {
+				require.Equal(t, tc.err, err)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.out, string(out))
+			}
+		})
+	}
+}
+
+func TestPluginValidate(t *testing.T) {
+	plugin := &Plugin{Name: "test", Command: "/bin/true"}
+	require.NoError(t, plugin.Validate())
+	require.Equal(t, DefaultPluginTimeout, plugin.Timeout)
+
+	plugin.Timeout = -time.Second
+	require.EqualError(t, plugin.Validate(), "invalid timeout -1s of plugin test")
+}
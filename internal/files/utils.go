@@ -19,8 +19,16 @@ package files
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
 )
 
+const backupTimeFormat = "20060102-150405.000"
+
+var backupSuffixRe = regexp.MustCompile(`^\.\d{8}-\d{6}\.\d{3}$`)
+
 func Validate(name, description string) error {
 	if len(name) == 0 {
 		return fmt.Errorf("missing filename for %s", description)
@@ -45,3 +53,87 @@ func Create(path string, data []byte) error {
 
 	return nil
 }
+
+// CreateAtomic writes data into a temporary file in the same directory and renames it to path,
+// so that readers never observe a partially written file
+func CreateAtomic(path string, data []byte) error {
+	dir, base := filepath.Split(path)
+	if len(dir) == 0 {
+		dir = "."
+	}
+
+	file, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %q: %v", path, err)
+	}
+	tmp := file.Name()
+	defer func() { _ = os.Remove(tmp) }()
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write to %q: %v", tmp, err)
+	}
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to sync %q: %v", tmp, err)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close %q: %v", tmp, err)
+	}
+
+	// preserve permissions of the existing file
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err = os.Chmod(tmp, mode); err != nil {
+		return fmt.Errorf("failed to set permissions on %q: %v", tmp, err)
+	}
+
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to rename %q to %q: %v", tmp, path, err)
+	}
+
+	return nil
+}
+
+// Backup writes data into a timestamped backup "<path>.<timestamp>"
+// and removes all but the latest keep backups of the path
+func Backup(path string, data []byte, keep int) (string, error) {
+	backup := fmt.Sprintf("%s.%s", path, time.Now().UTC().Format(backupTimeFormat))
+	if err := CreateAtomic(backup, data); err != nil {
+		return "", err
+	}
+
+	backups, err := ListBackups(path)
+	if err != nil {
+		return "", err
+	}
+
+	for len(backups) > keep {
+		if err = os.Remove(backups[0]); err != nil {
+			return "", fmt.Errorf("failed to remove backup %q: %v", backups[0], err)
+		}
+		backups = backups[1:]
+	}
+
+	return backup, nil
+}
+
+// ListBackups returns backups of the path created by Backup, sorted from oldest to newest
+func ListBackups(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups of %q: %v", path, err)
+	}
+
+	backups := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if backupSuffixRe.MatchString(match[len(path):]) {
+			backups = append(backups, match)
+		}
+	}
+	sort.Strings(backups)
+
+	return backups, nil
+}
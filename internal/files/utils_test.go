@@ -18,7 +18,9 @@ package files_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -70,3 +72,52 @@ func TestValidateFile(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topology.conf")
+
+	require.NoError(t, files.CreateAtomic(path, []byte("v1")))
+	require.NoError(t, os.Chmod(path, 0600))
+	require.NoError(t, files.CreateAtomic(path, []byte("v2")))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// no temporary files left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	err = files.CreateAtomic("/a/b/c", nil)
+	require.ErrorContains(t, err, `failed to create temporary file for "/a/b/c"`)
+}
+
+func TestBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "topology.conf")
+	require.NoError(t, os.WriteFile(path+".old", []byte("unrelated"), 0644))
+
+	var created []string
+	for i := range 4 {
+		backup, err := files.Backup(path, []byte{byte('0' + i)}, 2)
+		require.NoError(t, err)
+		created = append(created, backup)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	backups, err := files.ListBackups(path)
+	require.NoError(t, err)
+	require.Equal(t, created[2:], backups)
+
+	data, err := os.ReadFile(backups[1])
+	require.NoError(t, err)
+	require.Equal(t, "3", string(data))
+
+	_, err = os.Stat(path + ".old")
+	require.NoError(t, err)
+}
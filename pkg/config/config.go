@@ -25,7 +25,10 @@ import (
 	"gopkg.in/yaml.v3"
 	"k8s.io/klog/v2"
 
+	"github.com/NVIDIA/topograph/internal/exec"
 	"github.com/NVIDIA/topograph/internal/files"
+	execengine "github.com/NVIDIA/topograph/pkg/engines/exec"
+	execprovider "github.com/NVIDIA/topograph/pkg/providers/exec"
 	"github.com/NVIDIA/topograph/pkg/registry"
 )
 
@@ -35,15 +38,23 @@ type Config struct {
 	Provider                string            `yaml:"provider,omitempty"`
 	Engine                  string            `yaml:"engine,omitempty"`
 	PageSize                *int              `yaml:"pageSize,omitempty"`
+	MaxConcurrency          *int              `yaml:"maxConcurrency,omitempty"`
 	SSL                     *SSL              `yaml:"ssl,omitempty"`
 	CredsPath               *string           `yaml:"credentialsPath,omitempty"`
 	FwdSvcURL               *string           `yaml:"forwardServiceUrl,omitempty"`
 	Env                     map[string]string `yaml:"env"`
+	Plugins                 *Plugins          `yaml:"plugins,omitempty"`
 
 	// derived
 	Credentials map[string]string
 }
 
+// Plugins lists the external binaries registered as providers and engines
+type Plugins struct {
+	Providers []*exec.Plugin `yaml:"providers,omitempty"`
+	Engines   []*exec.Plugin `yaml:"engines,omitempty"`
+}
+
 type Endpoint struct {
 	Port int  `yaml:"port"`
 	SSL  bool `yaml:"ssl"`
@@ -79,6 +90,10 @@ func (cfg *Config) validate() error {
 		return fmt.Errorf("port is not set")
 	}
 
+	if err := cfg.registerPlugins(); err != nil {
+		return err
+	}
+
 	if cfg.Provider != "" {
 		_, ok := registry.Providers[cfg.Provider]
 		if !ok {
@@ -97,6 +112,10 @@ func (cfg *Config) validate() error {
 		return fmt.Errorf("requestAggregationDelay is not set")
 	}
 
+	if cfg.MaxConcurrency != nil && *cfg.MaxConcurrency <= 0 {
+		return fmt.Errorf("invalid maxConcurrency %d", *cfg.MaxConcurrency)
+	}
+
 	if cfg.HTTP.SSL {
 		if cfg.SSL == nil {
 			return fmt.Errorf("missing ssl section")
@@ -115,6 +134,37 @@ func (cfg *Config) validate() error {
 	return cfg.readCredentials()
 }
 
+// registerPlugins adds the plugins to the registry under their names
+func (cfg *Config) registerPlugins() error {
+	if cfg.Plugins == nil {
+		return nil
+	}
+
+	for _, plugin := range cfg.Plugins.Providers {
+		if err := plugin.Validate(); err != nil {
+			return err
+		}
+		if _, ok := registry.Providers[plugin.Name]; ok {
+			return fmt.Errorf("plugin %s conflicts with a registered provider", plugin.Name)
+		}
+		registry.Providers.Register(execprovider.NamedLoader(plugin))
+		klog.Infof("Registered provider plugin %s", plugin.Name)
+	}
+
+	for _, plugin := range cfg.Plugins.Engines {
+		if err := plugin.Validate(); err != nil {
+			return err
+		}
+		if _, ok := registry.Engines[plugin.Name]; ok {
+			return fmt.Errorf("plugin %s conflicts with a registered engine", plugin.Name)
+		}
+		registry.Engines.Register(execengine.NamedLoader(plugin))
+		klog.Infof("Registered engine plugin %s", plugin.Name)
+	}
+
+	return nil
+}
+
 func (cfg *Config) UpdateEnv() (err error) {
 	for env, val := range cfg.Env {
 		if env == "PATH" { // special case for PATH env var
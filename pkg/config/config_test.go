@@ -24,6 +24,9 @@ import (
 
 	"github.com/agrea/ptr"
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/pkg/registry"
 )
 
 const (
@@ -38,6 +41,7 @@ http:
   ssl: true
 requestAggregationDelay: 15s
 pageSize: 50
+maxConcurrency: 8
 ssl:
   cert: %s
   key: %s
@@ -92,6 +96,7 @@ func TestConfig(t *testing.T) {
 		},
 		RequestAggregationDelay: 15 * time.Second,
 		PageSize:                ptr.Int(50),
+		MaxConcurrency:          ptr.Int(8),
 		SSL: &SSL{
 			Cert:   cert.Name(),
 			Key:    key.Name(),
@@ -154,6 +159,17 @@ func TestValidate(t *testing.T) {
 			},
 			err: "requestAggregationDelay is not set",
 		},
+		{
+			name: "Case 2.1: invalid maxConcurrency",
+			cfg: Config{
+				HTTP: Endpoint{
+					Port: 1,
+				},
+				RequestAggregationDelay: time.Second,
+				MaxConcurrency:          ptr.Int(0),
+			},
+			err: "invalid maxConcurrency 0",
+		},
 		{
 			name: "Case 3: missing ssl section",
 			cfg: Config{
@@ -244,3 +260,66 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterPlugins(t *testing.T) {
+	testCases := []struct {
+		name    string
+		plugins *Plugins
+		err     string
+	}{
+		{
+			name: "Case 1: missing plugin name",
+			plugins: &Plugins{
+				Providers: []*exec.Plugin{{Command: "/bin/true"}},
+			},
+			err: "missing plugin name",
+		},
+		{
+			name: "Case 2: missing command",
+			plugins: &Plugins{
+				Engines: []*exec.Plugin{{Name: "inventory"}},
+			},
+			err: "missing command of plugin inventory",
+		},
+		{
+			name: "Case 3: registered provider",
+			plugins: &Plugins{
+				Providers: []*exec.Plugin{{Name: "aws", Command: "/bin/true"}},
+			},
+			err: "plugin aws conflicts with a registered provider",
+		},
+		{
+			name: "Case 4: registered engine",
+			plugins: &Plugins{
+				Engines: []*exec.Plugin{{Name: "slurm", Command: "/bin/true"}},
+			},
+			err: "plugin slurm conflicts with a registered engine",
+		},
+		{
+			name: "Case 5: valid input",
+			plugins: &Plugins{
+				Providers: []*exec.Plugin{{Name: "inventory", Command: "/bin/true"}},
+				Engines:   []*exec.Plugin{{Name: "inventory", Command: "/bin/true", Timeout: time.Second}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{Plugins: tc.plugins}
+			err := cfg.registerPlugins()
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			defer delete(registry.Providers, "inventory")
+			defer delete(registry.Engines, "inventory")
+
+			require.Contains(t, registry.Providers, "inventory")
+			require.Contains(t, registry.Engines, "inventory")
+			require.Equal(t, exec.DefaultPluginTimeout, tc.plugins.Providers[0].Timeout)
+			require.Equal(t, time.Second, tc.plugins.Engines[0].Timeout)
+		})
+	}
+}
@@ -26,6 +26,16 @@ import (
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
+// UnchangedResult is returned instead of "OK" when the generated topology config
+// matches the deployed one and no update was performed
+const UnchangedResult = "UNCHANGED\n"
+
+// ErrNodesRequired is returned by the engines that cannot discover the nodes,
+// which are provided in the request or listed by the simulation and test providers
+func ErrNodesRequired(engine string) *httperr.Error {
+	return httperr.NewError(http.StatusBadRequest, fmt.Sprintf("%s engine requires the nodes in the request", engine))
+}
+
 type Engine interface {
 	GetComputeInstances(ctx context.Context, environment Environment) ([]topology.ComputeInstances, *httperr.Error)
 	GenerateOutput(ctx context.Context, vertex *topology.Vertex, params map[string]any) ([]byte, *httperr.Error)
@@ -33,7 +43,10 @@ type Engine interface {
 
 type Environment any
 
-type Config = map[string]any
+type Config struct {
+	Creds  map[string]string
+	Params map[string]any
+}
 type NamedLoader = component.NamedLoader[Engine, Config]
 type Loader = component.Loader[Engine, Config]
 type Registry component.Registry[Engine, Config]
@@ -42,6 +55,11 @@ func NewRegistry(namedLoaders ...NamedLoader) Registry {
 	return Registry(component.NewRegistry(namedLoaders...))
 }
 
+// Register adds name/loader pairs to an existing Registry
+func (r Registry) Register(namedLoaders ...NamedLoader) {
+	component.Registry[Engine, Config](r).Register(namedLoaders...)
+}
+
 func (r Registry) Get(name string) (Loader, *httperr.Error) {
 	loader, ok := r[name]
 	if !ok {
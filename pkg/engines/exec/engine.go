@@ -0,0 +1,59 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package exec
+
+import (
+	"context"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/json"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// ExecEngine runs an external plugin, which receives the Request on stdin
+// and writes the output to stdout
+type ExecEngine struct {
+	plugin *exec.Plugin
+	creds  map[string]string
+}
+
+// Request is the plugin request of the exec engine
+type Request struct {
+	Version  string            `json:"version"`
+	Topology *json.Document    `json:"topology"`
+	Params   map[string]any    `json:"params,omitempty"`
+	Creds    map[string]string `json:"creds,omitempty"`
+}
+
+// NamedLoader returns the loader of the engine plugin, registered under the plugin name
+func NamedLoader(plugin *exec.Plugin) engines.NamedLoader {
+	return func() (string, engines.Loader) {
+		return plugin.Name, Loader(plugin)
+	}
+}
+
+func Loader(plugin *exec.Plugin) engines.Loader {
+	return func(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+		return &ExecEngine{plugin: plugin, creds: cfg.Creds}, nil
+	}
+}
+
+// GetComputeInstances is not supported: the nodes are provided in the request
+func (eng *ExecEngine) GetComputeInstances(_ context.Context, _ engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, engines.ErrNodesRequired(eng.plugin.Name)
+}
+
+// GenerateOutput returns the plugin stdout
+func (eng *ExecEngine) GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	return eng.plugin.Run(ctx, &Request{
+		Version:  exec.PluginVersion,
+		Topology: json.ToDocument(root),
+		Params:   params,
+		Creds:    eng.creds,
+	})
+}
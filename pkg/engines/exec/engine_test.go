@@ -0,0 +1,47 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package exec
+
+import (
+	"context"
+	encjson "encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/json"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestExecEngine(t *testing.T) {
+	ctx := context.TODO()
+	plugin := &exec.Plugin{Name: "scheduler", Command: "/bin/cat", Timeout: time.Second}
+	name, loader := NamedLoader(plugin)()
+	require.Equal(t, "scheduler", name)
+
+	eng, httpErr := loader(ctx, engines.Config{Creds: map[string]string{"token": "secret"}})
+	require.Nil(t, httpErr)
+
+	_, httpErr = eng.GetComputeInstances(ctx, nil)
+	require.EqualError(t, httpErr, "scheduler engine requires the nodes in the request")
+
+	root, _ := translate.GetTreeTestSet(false)
+	data, httpErr := eng.GenerateOutput(ctx, root, map[string]any{"partition": "gpu"})
+	require.Nil(t, httpErr)
+
+	// the plugin echoes the request
+	req := &Request{}
+	require.NoError(t, encjson.Unmarshal(data, req))
+	require.Equal(t, &Request{
+		Version:  exec.PluginVersion,
+		Topology: json.ToDocument(root),
+		Params:   map[string]any{"partition": "gpu"},
+		Creds:    map[string]string{"token": "secret"},
+	}, req)
+}
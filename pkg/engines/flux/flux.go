@@ -0,0 +1,167 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package flux
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "flux"
+
+	defaultClusterName = "cluster"
+)
+
+type FluxEngine struct {
+	params *Params
+	// ranks maps the discovered node names to their broker ranks
+	ranks map[string]int
+}
+
+type Params struct {
+	// TopoConfigPath (optional) specifies the file path for the JGF resource graph;
+	// if omitted, the JGF is returned in the response
+	TopoConfigPath string `mapstructure:"topologyConfigPath"`
+	// ClusterName (optional) specifies the basename of the cluster vertex
+	ClusterName string `mapstructure:"clusterName"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParams(cfg.Params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &FluxEngine{params: p}, nil
+}
+
+func getParams(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+	if len(p.ClusterName) == 0 {
+		p.ClusterName = defaultClusterName
+	}
+	return p, nil
+}
+
+func (eng *FluxEngine) GetComputeInstances(ctx context.Context, environment engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	ranks, err := GetNodeRanks(ctx)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+	eng.ranks = ranks
+
+	nodes := slices.Sorted(maps.Keys(ranks))
+
+	return engines.MapComputeInstances(ctx, environment, nodes)
+}
+
+// GetNodeRanks returns the map of Flux node names to their broker ranks reported by "flux resource list"
+func GetNodeRanks(ctx context.Context) (map[string]int, error) {
+	stdout, err := exec.Exec(ctx, "flux", []string{"resource", "list", "--no-header", "-o", "{ranks} {nodelist}"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("stdout: %s", stdout.String())
+
+	return parseNodeRanks(stdout.String())
+}
+
+// parseNodeRanks parses "flux resource list" output lines with rank idsets and node lists;
+// the ranks are listed in the node list order
+func parseNodeRanks(data string) (map[string]int, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected flux resource list line %q", scanner.Text())
+		}
+
+		ids := cluset.ExpandList("[" + fields[0] + "]")
+		nodes := cluset.ExpandList(fields[1])
+		if len(ids) != len(nodes) {
+			return nil, fmt.Errorf("ranks %q do not match nodes %q", fields[0], fields[1])
+		}
+		for i, node := range nodes {
+			rank, err := strconv.Atoi(ids[i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid rank %q: %v", ids[i], err)
+			}
+			ranks[node] = rank
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan flux resource list output: %v", err)
+	}
+
+	return ranks, nil
+}
+
+func (eng *FluxEngine) GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	p, err := getParams(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return generateOutput(root, p, eng.ranks)
+}
+
+func GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	p, err := getParams(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return GenerateOutputParams(ctx, root, p)
+}
+
+// GenerateOutputParams generates the JGF resource graph without broker ranks
+func GenerateOutputParams(_ context.Context, root *topology.Vertex, params *Params) ([]byte, *httperr.Error) {
+	return generateOutput(root, params, nil)
+}
+
+func generateOutput(root *topology.Vertex, params *Params, ranks map[string]int) ([]byte, *httperr.Error) {
+	data, err := ToJGF(root, params.ClusterName, ranks).Marshal()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	path := params.TopoConfigPath
+	if len(path) == 0 {
+		klog.Info("Returning JGF resource graph")
+		return data, nil
+	}
+
+	return engines.WriteOutput(NAME, path, data, nil)
+}
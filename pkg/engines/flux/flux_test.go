@@ -0,0 +1,100 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package flux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const testResourceList = `0-1 node[1-2]
+3,5 node3,node5
+
+`
+
+type testInstanceMapper struct{}
+
+func (m *testInstanceMapper) Instances2NodeMap(_ context.Context, nodes []string) (map[string]string, error) {
+	i2n := make(map[string]string)
+	for _, node := range nodes {
+		i2n["i-"+node] = node
+	}
+	return i2n, nil
+}
+
+func (m *testInstanceMapper) GetInstancesRegions(_ context.Context, nodes []string) (map[string]string, error) {
+	regions := make(map[string]string)
+	for _, node := range nodes {
+		regions[node] = "region"
+	}
+	return regions, nil
+}
+
+func TestParseNodeRanks(t *testing.T) {
+	ranks, err := parseNodeRanks(testResourceList)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"node1": 0, "node2": 1, "node3": 3, "node5": 5}, ranks)
+
+	_, err = parseNodeRanks("0-2 node[1-2]\n")
+	require.EqualError(t, err, `ranks "0-2" do not match nodes "node[1-2]"`)
+
+	_, err = parseNodeRanks("free 0-1 node[1-2]\n")
+	require.EqualError(t, err, `unexpected flux resource list line "free 0-1 node[1-2]"`)
+}
+
+func TestFluxEngine(t *testing.T) {
+	ctx := context.TODO()
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\nprintf '" + testResourceList + "'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flux"), []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	path := filepath.Join(t.TempDir(), "resources.json")
+	params := map[string]any{"topologyConfigPath": path, "clusterName": "flux"}
+	eng, httpErr := Loader(ctx, engines.Config{Params: params})
+	require.Nil(t, httpErr)
+
+	_, httpErr = eng.GetComputeInstances(ctx, nil)
+	require.EqualError(t, httpErr, "environment must implement instanceMapper")
+
+	cis, httpErr := eng.GetComputeInstances(ctx, &testInstanceMapper{})
+	require.Nil(t, httpErr)
+	require.Equal(t, []topology.ComputeInstances{{
+		Region: "region",
+		Instances: map[string]string{
+			"i-node1": "node1", "i-node2": "node2", "i-node3": "node3", "i-node5": "node5",
+		},
+	}}, cis)
+
+	root := getTestGraph()
+	out, httpErr := eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(out))
+
+	expected, err := ToJGF(root, "flux", map[string]int{"node1": 0, "node2": 1, "node3": 3, "node5": 5}).Marshal()
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(data))
+
+	out, httpErr = eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, engines.UnchangedResult, string(out))
+
+	out, httpErr = GenerateOutput(ctx, root, map[string]any{})
+	require.Nil(t, httpErr)
+	expected, err = ToJGF(root, defaultClusterName, nil).Marshal()
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(out))
+}
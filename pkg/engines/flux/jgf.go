@@ -0,0 +1,259 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package flux
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// JGF vertex types
+const (
+	TypeCluster = "cluster"
+	TypeSwitch  = "switch"
+	TypeRack    = "rack"
+	TypeNode    = "node"
+
+	containment = "containment"
+)
+
+var nodeNameRe = regexp.MustCompile(`^(.*?)(\d+)$`)
+
+// JGF is the Flux JSON Graph Format resource graph
+type JGF struct {
+	Graph Graph `json:"graph"`
+}
+
+type Graph struct {
+	Directed bool    `json:"directed"`
+	Nodes    []*Node `json:"nodes"`
+	Edges    []*Edge `json:"edges"`
+}
+
+type Node struct {
+	ID       string       `json:"id"`
+	Metadata NodeMetadata `json:"metadata"`
+}
+
+type NodeMetadata struct {
+	Type      string            `json:"type"`
+	Basename  string            `json:"basename"`
+	Name      string            `json:"name"`
+	ID        int               `json:"id"`
+	UniqID    int               `json:"uniq_id"`
+	Rank      int               `json:"rank"`
+	Exclusive bool              `json:"exclusive"`
+	Unit      string            `json:"unit"`
+	Size      int               `json:"size"`
+	Paths     map[string]string `json:"paths"`
+}
+
+type Edge struct {
+	Source   string       `json:"source"`
+	Target   string       `json:"target"`
+	Metadata EdgeMetadata `json:"metadata"`
+}
+
+type EdgeMetadata struct {
+	Name map[string]string `json:"name"`
+}
+
+// jgfBuilder adds vertices and containment edges to the resource graph
+type jgfBuilder struct {
+	jgf   *JGF
+	ranks map[string]int
+	// nextRank is the rank of the next node without a broker rank
+	nextRank int
+	// ids holds the next ID of every vertex type
+	ids map[string]int
+	// added holds the names of the added nodes
+	added map[string]bool
+}
+
+// ToJGF converts the topology graph into the Flux resource graph.
+// The cluster vertex contains the tree switches; every leaf switch contains
+// a rack vertex per block of its nodes. Nodes without a block are contained
+// in their leaf switch, nodes without tree data in their block rack,
+// and nodes without topology data in the cluster.
+func ToJGF(root *topology.Vertex, clusterName string, ranks map[string]int) *JGF {
+	b := &jgfBuilder{
+		jgf:   &JGF{Graph: Graph{Directed: true, Nodes: []*Node{}, Edges: []*Edge{}}},
+		ranks: ranks,
+		ids:   make(map[string]int),
+		added: make(map[string]bool),
+	}
+	// the nodes without a broker rank are ranked after the known ones
+	for _, rank := range ranks {
+		b.nextRank = max(b.nextRank, rank+1)
+	}
+
+	cluster := b.addVertex(nil, TypeCluster, clusterName, clusterName+"0", 0, -1)
+
+	// nodeBlocks maps node names to their blocks
+	nodeBlocks := make(map[string]*topology.Vertex)
+	var blocks []*topology.Vertex
+	if blockRoot, ok := root.Vertices[topology.TopologyBlock]; ok {
+		blocks = sortedVertices(blockRoot)
+		for _, block := range blocks {
+			for _, node := range block.Vertices {
+				nodeBlocks[node.Name] = block
+			}
+		}
+	}
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok {
+		for _, v := range sortedVertices(tree) {
+			if v.ID == topology.NoTopology {
+				for _, node := range sortedVertices(v) {
+					if _, ok := nodeBlocks[node.Name]; !ok {
+						b.addNode(cluster, node)
+					}
+				}
+				continue
+			}
+			b.addSwitch(cluster, v, nodeBlocks)
+		}
+	}
+
+	// blocks of the nodes without tree data
+	for _, block := range blocks {
+		var rack *Node
+		for _, node := range sortedVertices(block) {
+			if b.added[node.Name] {
+				continue
+			}
+			if rack == nil {
+				rack = b.addRack(cluster, block)
+			}
+			b.addNode(rack, node)
+		}
+	}
+
+	return b.jgf
+}
+
+// addSwitch adds the switch with its descendants
+func (b *jgfBuilder) addSwitch(parent *Node, v *topology.Vertex, nodeBlocks map[string]*topology.Vertex) {
+	name := v.Name
+	if len(name) == 0 {
+		name = v.ID
+	}
+	sw := b.addVertex(parent, TypeSwitch, TypeSwitch, name, b.nextID(TypeSwitch), -1)
+
+	racks := make(map[string]*Node)
+	for _, w := range sortedVertices(v) {
+		if len(w.Vertices) != 0 {
+			b.addSwitch(sw, w, nodeBlocks)
+			continue
+		}
+		block, ok := nodeBlocks[w.Name]
+		if !ok {
+			b.addNode(sw, w)
+			continue
+		}
+		rack, ok := racks[block.ID]
+		if !ok {
+			rack = b.addRack(sw, block)
+			racks[block.ID] = rack
+		}
+		b.addNode(rack, w)
+	}
+}
+
+func (b *jgfBuilder) addRack(parent *Node, block *topology.Vertex) *Node {
+	return b.addVertex(parent, TypeRack, TypeRack, block.ID, b.nextID(TypeRack), -1)
+}
+
+func (b *jgfBuilder) addNode(parent *Node, v *topology.Vertex) {
+	name := v.Name
+	if len(name) == 0 {
+		name = v.ID
+	}
+
+	basename, id := name, -1
+	if match := nodeNameRe.FindStringSubmatch(name); match != nil {
+		if n, err := strconv.Atoi(match[2]); err == nil {
+			basename, id = match[1], n
+		}
+	}
+	if id < 0 {
+		id = b.nextID(TypeNode)
+	}
+
+	rank, ok := b.ranks[name]
+	if !ok {
+		rank = b.nextRank
+		b.nextRank++
+	}
+
+	b.addVertex(parent, TypeNode, basename, name, id, rank)
+	b.added[name] = true
+}
+
+// addVertex adds the vertex and its containment edge from the parent
+func (b *jgfBuilder) addVertex(parent *Node, typ, basename, name string, id, rank int) *Node {
+	uniqID := len(b.jgf.Graph.Nodes)
+	vertexPath := "/" + name
+	if parent != nil {
+		vertexPath = path.Join(parent.Metadata.Paths[containment], name)
+	}
+
+	// the vertex ID is prefixed with the type to keep the vertices of different types apart
+	node := &Node{
+		ID: typ + "/" + strconv.Itoa(uniqID),
+		Metadata: NodeMetadata{
+			Type:     typ,
+			Basename: basename,
+			Name:     name,
+			ID:       id,
+			UniqID:   uniqID,
+			Rank:     rank,
+			Size:     1,
+			Paths:    map[string]string{containment: vertexPath},
+		},
+	}
+	b.jgf.Graph.Nodes = append(b.jgf.Graph.Nodes, node)
+
+	if parent != nil {
+		b.jgf.Graph.Edges = append(b.jgf.Graph.Edges, &Edge{
+			Source:   parent.ID,
+			Target:   node.ID,
+			Metadata: EdgeMetadata{Name: map[string]string{containment: "contains"}},
+		})
+	}
+
+	return node
+}
+
+func (b *jgfBuilder) nextID(typ string) int {
+	id := b.ids[typ]
+	b.ids[typ] = id + 1
+	return id
+}
+
+// sortedVertices returns the child vertices sorted by ID
+func sortedVertices(v *topology.Vertex) []*topology.Vertex {
+	vertices := slices.Collect(maps.Values(v.Vertices))
+	sort.Slice(vertices, func(i, j int) bool { return vertices[i].ID < vertices[j].ID })
+	return vertices
+}
+
+// Marshal returns the indented JSON representation of the resource graph
+func (jgf *JGF) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(jgf, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JGF: %v", err)
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package flux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func getTestGraph() *topology.Vertex {
+	return &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"spine": {
+						ID: "spine",
+						Vertices: map[string]*topology.Vertex{
+							"leaf": {
+								ID:   "leaf",
+								Name: "leaf1",
+								Vertices: map[string]*topology.Vertex{
+									"i1": {ID: "i1", Name: "node1"},
+									"i2": {ID: "i2", Name: "node2"},
+									"i3": {ID: "i3", Name: "node3"},
+								},
+							},
+						},
+					},
+					topology.NoTopology: {
+						ID: topology.NoTopology,
+						Vertices: map[string]*topology.Vertex{
+							"i4": {ID: "i4", Name: "cpu"},
+							"i5": {ID: "i5", Name: "node5"},
+						},
+					},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"nvl1": {
+						ID:   "block001",
+						Name: "nvl1",
+						Vertices: map[string]*topology.Vertex{
+							"node1": {ID: "i1", Name: "node1"},
+							"node2": {ID: "i2", Name: "node2"},
+						},
+					},
+					"nvl2": {
+						ID:   "block002",
+						Name: "nvl2",
+						Vertices: map[string]*topology.Vertex{
+							"node5": {ID: "i5", Name: "node5"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestToJGF(t *testing.T) {
+	jgf := ToJGF(getTestGraph(), "flux", map[string]int{"node1": 0, "node2": 1, "node3": 2})
+
+	type vertex struct {
+		typ, basename string
+		id, rank      int
+		path          string
+	}
+	expected := []vertex{
+		{TypeCluster, "flux", 0, -1, "/flux0"},
+		{TypeNode, "cpu", 0, 3, "/flux0/cpu"},
+		{TypeSwitch, TypeSwitch, 0, -1, "/flux0/spine"},
+		{TypeSwitch, TypeSwitch, 1, -1, "/flux0/spine/leaf1"},
+		{TypeRack, TypeRack, 0, -1, "/flux0/spine/leaf1/block001"},
+		{TypeNode, "node", 1, 0, "/flux0/spine/leaf1/block001/node1"},
+		{TypeNode, "node", 2, 1, "/flux0/spine/leaf1/block001/node2"},
+		{TypeNode, "node", 3, 2, "/flux0/spine/leaf1/node3"},
+		{TypeRack, TypeRack, 1, -1, "/flux0/block002"},
+		{TypeNode, "node", 5, 4, "/flux0/block002/node5"},
+	}
+
+	vertices := make([]vertex, 0, len(jgf.Graph.Nodes))
+	for i, node := range jgf.Graph.Nodes {
+		require.Equal(t, i, node.Metadata.UniqID)
+		m := node.Metadata
+		vertices = append(vertices, vertex{m.Type, m.Basename, m.ID, m.Rank, m.Paths[containment]})
+	}
+	require.Equal(t, expected, vertices)
+
+	edges := make([][2]string, 0, len(jgf.Graph.Edges))
+	for _, edge := range jgf.Graph.Edges {
+		require.Equal(t, map[string]string{containment: "contains"}, edge.Metadata.Name)
+		edges = append(edges, [2]string{edge.Source, edge.Target})
+	}
+	require.Equal(t, [][2]string{
+		{"cluster/0", "node/1"}, {"cluster/0", "switch/2"}, {"switch/2", "switch/3"}, {"switch/3", "rack/4"},
+		{"rack/4", "node/5"}, {"rack/4", "node/6"}, {"switch/3", "node/7"}, {"cluster/0", "rack/8"}, {"rack/8", "node/9"},
+	}, edges)
+}
+
+func TestMarshalJGF(t *testing.T) {
+	root := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"sw1": {
+						ID:       "sw1",
+						Vertices: map[string]*topology.Vertex{"i1": {ID: "i1", Name: "node1"}},
+					},
+				},
+			},
+		},
+	}
+
+	expected := `{
+  "graph": {
+    "directed": true,
+    "nodes": [
+      {
+        "id": "cluster/0",
+        "metadata": {
+          "type": "cluster",
+          "basename": "cluster",
+          "name": "cluster0",
+          "id": 0,
+          "uniq_id": 0,
+          "rank": -1,
+          "exclusive": false,
+          "unit": "",
+          "size": 1,
+          "paths": {
+            "containment": "/cluster0"
+          }
+        }
+      },
+      {
+        "id": "switch/1",
+        "metadata": {
+          "type": "switch",
+          "basename": "switch",
+          "name": "sw1",
+          "id": 0,
+          "uniq_id": 1,
+          "rank": -1,
+          "exclusive": false,
+          "unit": "",
+          "size": 1,
+          "paths": {
+            "containment": "/cluster0/sw1"
+          }
+        }
+      },
+      {
+        "id": "node/2",
+        "metadata": {
+          "type": "node",
+          "basename": "node",
+          "name": "node1",
+          "id": 1,
+          "uniq_id": 2,
+          "rank": 0,
+          "exclusive": false,
+          "unit": "",
+          "size": 1,
+          "paths": {
+            "containment": "/cluster0/sw1/node1"
+          }
+        }
+      }
+    ],
+    "edges": [
+      {
+        "source": "cluster/0",
+        "target": "switch/1",
+        "metadata": {
+          "name": {
+            "containment": "contains"
+          }
+        }
+      },
+      {
+        "source": "switch/1",
+        "target": "node/2",
+        "metadata": {
+          "name": {
+            "containment": "contains"
+          }
+        }
+      }
+    ]
+  }
+}
+`
+	data, err := ToJGF(root, defaultClusterName, nil).Marshal()
+	require.NoError(t, err)
+	require.Equal(t, expected, string(data))
+}
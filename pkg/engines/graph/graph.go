@@ -0,0 +1,128 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "graph"
+
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+type GraphEngine struct{}
+
+type Params struct {
+	// TopoConfigPath (optional) specifies the file path for the rendered graph;
+	// if omitted, the graph is returned in the response
+	TopoConfigPath string `mapstructure:"topologyConfigPath"`
+	// Format (optional) specifies the output format: "dot" (default) for Graphviz, or "mermaid"
+	Format string `mapstructure:"format"`
+	// CollapseLeaves (optional) replaces the nodes of every switch and block with their count
+	CollapseLeaves bool `mapstructure:"collapseLeaves"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	if _, err := getParams(cfg.Params); err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &GraphEngine{}, nil
+}
+
+func getParams(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	switch p.Format {
+	case "":
+		p.Format = FormatDOT
+	case FormatDOT, FormatMermaid:
+	default:
+		return nil, fmt.Errorf("unsupported format %q", p.Format)
+	}
+
+	return p, nil
+}
+
+// GetComputeInstances is not supported: the nodes are provided in the request
+func (eng *GraphEngine) GetComputeInstances(_ context.Context, _ engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, engines.ErrNodesRequired(NAME)
+}
+
+func (eng *GraphEngine) GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	return GenerateOutput(ctx, root, params)
+}
+
+func GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	p, err := getParams(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return GenerateOutputParams(ctx, root, p)
+}
+
+// GenerateOutputParams renders the topology graph in the requested format
+func GenerateOutputParams(_ context.Context, root *topology.Vertex, params *Params) ([]byte, *httperr.Error) {
+	g := newGraph(root, params.CollapseLeaves)
+
+	var data []byte
+	if params.Format == FormatMermaid {
+		data = g.toMermaid()
+	} else {
+		data = g.toDOT()
+	}
+
+	path := params.TopoConfigPath
+	if len(path) == 0 {
+		klog.Infof("Returning %s graph", params.Format)
+		return data, nil
+	}
+
+	return engines.WriteOutput(NAME, path, data, func(current, data []byte) bool {
+		return bytes.Equal(stripHeader(current), stripHeader(data))
+	})
+}
+
+// stripHeader removes the header comments, such as "generated_at"
+func stripHeader(data []byte) []byte {
+	for {
+		stripped := false
+		for _, prefix := range []string{"// ", "%% "} {
+			for _, key := range topology.HeaderKeys {
+				if bytes.HasPrefix(data, []byte(prefix+key+":")) {
+					if i := bytes.IndexByte(data, '\n'); i >= 0 {
+						data = data[i+1:]
+						stripped = true
+					}
+				}
+			}
+		}
+		if !stripped {
+			return data
+		}
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package graph
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestGetParams(t *testing.T) {
+	p, err := getParams(nil)
+	require.NoError(t, err)
+	require.Equal(t, &Params{Format: FormatDOT}, p)
+
+	p, err = getParams(map[string]any{"format": "mermaid", "collapseLeaves": true})
+	require.NoError(t, err)
+	require.Equal(t, &Params{Format: FormatMermaid, CollapseLeaves: true}, p)
+
+	_, err = getParams(map[string]any{"format": "svg"})
+	require.EqualError(t, err, `unsupported format "svg"`)
+}
+
+func TestGraphEngine(t *testing.T) {
+	ctx := context.TODO()
+
+	_, httpErr := Loader(ctx, engines.Config{Params: map[string]any{"format": "svg"}})
+	require.EqualError(t, httpErr, `unsupported format "svg"`)
+
+	eng, httpErr := Loader(ctx, engines.Config{})
+	require.Nil(t, httpErr)
+
+	_, httpErr = eng.GetComputeInstances(ctx, nil)
+	require.EqualError(t, httpErr, "graph engine requires the nodes in the request")
+
+	data, httpErr := eng.GenerateOutput(ctx, getTestGraph(), map[string]any{"format": "mermaid"})
+	require.Nil(t, httpErr)
+	require.Equal(t, string(newGraph(getTestGraph(), false).toMermaid()), string(data))
+
+	path := filepath.Join(t.TempDir(), "topology.dot")
+	params := map[string]any{"topologyConfigPath": path}
+	data, httpErr = eng.GenerateOutput(ctx, getTestGraph(), params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(data))
+
+	dot, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(newGraph(getTestGraph(), false).toDOT()), string(dot))
+
+	// new headers do not change the graph
+	root := getTestGraph()
+	root.Metadata[topology.KeyGeneratedAt] = "2026-01-02T00:00:00Z"
+	root.Metadata[topology.KeyProvider] = "oci-imds"
+	data, httpErr = eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, engines.UnchangedResult, string(data))
+
+	delete(root.Vertices, topology.TopologyBlock)
+	data, httpErr = eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(data))
+}
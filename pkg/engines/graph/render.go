@@ -0,0 +1,273 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	missingFill   = "#f4cccc"
+	missingStroke = "#cc0000"
+)
+
+// graph is the rendered view of the topology, with vertex IDs valid in both DOT and Mermaid
+type graph struct {
+	// header lists the root metadata and warnings emitted as header comments, such as "generated_at"
+	header   [][2]string
+	switches []*vertex
+	// leaves lists the compute nodes, or the node counts if the leaves are collapsed
+	leaves []*vertex
+	blocks []*cluster
+	edges  []edge
+}
+
+type vertex struct {
+	id    string
+	label []string
+	// block is the ID of the block cluster containing the vertex
+	block string
+	// missing is set for the nodes without topology data
+	missing bool
+}
+
+type cluster struct {
+	id    string
+	label string
+}
+
+type edge struct {
+	from, to string
+}
+
+// leaf is a compute node and its position in the topology
+type leaf struct {
+	name, instance string
+	parent, block  string
+	missing        bool
+}
+
+// newGraph converts the topology into a graph. Switches are connected to their children,
+// NVLink blocks become clusters of their nodes, and nodes without topology are marked missing.
+// If collapse is set, the nodes sharing the parent switch, block and missing state are replaced
+// with a single vertex counting them.
+func newGraph(root *topology.Vertex, collapse bool) *graph {
+	g := &graph{header: root.Header()}
+
+	leaves := []*leaf{}
+	index := make(map[string]*leaf)
+	addLeaf := func(v *topology.Vertex, parent string, missing bool) *leaf {
+		if l, ok := index[v.Name]; ok {
+			return l
+		}
+		l := &leaf{name: v.Name, instance: v.ID, parent: parent, missing: missing}
+		leaves = append(leaves, l)
+		index[v.Name] = l
+		return l
+	}
+
+	var addSwitch func(v *topology.Vertex, parent string)
+	addSwitch = func(v *topology.Vertex, parent string) {
+		sw := &vertex{id: fmt.Sprintf("s%d", len(g.switches)+1), label: labelLines(v.ID, v.Name)}
+		g.switches = append(g.switches, sw)
+		if len(parent) != 0 {
+			g.edges = append(g.edges, edge{from: parent, to: sw.id})
+		}
+		for _, key := range slices.Sorted(maps.Keys(v.Vertices)) {
+			w := v.Vertices[key]
+			if len(w.Vertices) != 0 {
+				addSwitch(w, sw.id)
+			} else {
+				addLeaf(w, sw.id, false)
+			}
+		}
+	}
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok {
+		for _, key := range slices.Sorted(maps.Keys(tree.Vertices)) {
+			v := tree.Vertices[key]
+			if v.ID != topology.NoTopology {
+				addSwitch(v, "")
+				continue
+			}
+			for _, name := range slices.Sorted(maps.Keys(v.Vertices)) {
+				addLeaf(v.Vertices[name], "", true)
+			}
+		}
+	}
+
+	if blocks, ok := root.Vertices[topology.TopologyBlock]; ok {
+		for _, key := range slices.Sorted(maps.Keys(blocks.Vertices)) {
+			block := blocks.Vertices[key]
+			label := block.ID
+			if len(block.Name) != 0 && block.Name != block.ID {
+				label = fmt.Sprintf("%s (%s)", block.ID, block.Name)
+			}
+			c := &cluster{id: fmt.Sprintf("b%d", len(g.blocks)+1), label: label}
+			g.blocks = append(g.blocks, c)
+			for _, name := range slices.Sorted(maps.Keys(block.Vertices)) {
+				addLeaf(block.Vertices[name], "", false).block = c.id
+			}
+		}
+	}
+
+	if collapse {
+		g.addCollapsedLeaves(leaves)
+	} else {
+		g.addLeaves(leaves)
+	}
+
+	return g
+}
+
+func (g *graph) addLeaves(leaves []*leaf) {
+	for i, l := range leaves {
+		v := &vertex{id: fmt.Sprintf("n%d", i+1), label: labelLines(l.name, l.instance), block: l.block, missing: l.missing}
+		g.leaves = append(g.leaves, v)
+		if len(l.parent) != 0 {
+			g.edges = append(g.edges, edge{from: l.parent, to: v.id})
+		}
+	}
+}
+
+func (g *graph) addCollapsedLeaves(leaves []*leaf) {
+	type groupKey struct {
+		parent, block string
+		missing       bool
+	}
+	groups := make(map[groupKey]int)
+	keys := []groupKey{}
+	for _, l := range leaves {
+		key := groupKey{parent: l.parent, block: l.block, missing: l.missing}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key]++
+	}
+
+	for i, key := range keys {
+		label := fmt.Sprintf("%d nodes", groups[key])
+		if groups[key] == 1 {
+			label = "1 node"
+		}
+		v := &vertex{id: fmt.Sprintf("n%d", i+1), label: []string{label}, block: key.block, missing: key.missing}
+		g.leaves = append(g.leaves, v)
+		if len(key.parent) != 0 {
+			g.edges = append(g.edges, edge{from: key.parent, to: v.id})
+		}
+	}
+}
+
+// labelLines returns the vertex name followed by the ID if they differ
+func labelLines(first, second string) []string {
+	if len(first) == 0 {
+		return []string{second}
+	}
+	if len(second) == 0 || first == second {
+		return []string{first}
+	}
+	return []string{first, second}
+}
+
+// blockLeaves returns the leaves of the block cluster; an empty ID returns the leaves outside of blocks
+func (g *graph) blockLeaves(id string) []*vertex {
+	leaves := []*vertex{}
+	for _, v := range g.leaves {
+		if v.block == id {
+			leaves = append(leaves, v)
+		}
+	}
+	return leaves
+}
+
+// toDOT renders the graph in the Graphviz DOT language
+func (g *graph) toDOT() []byte {
+	buf := &bytes.Buffer{}
+	for _, h := range g.header {
+		fmt.Fprintf(buf, "// %s: %s\n", h[0], h[1])
+	}
+	buf.WriteString("digraph topology {\n  node [shape=box];\n")
+
+	writeVertex := func(indent string, v *vertex, attrs string) {
+		if v.missing {
+			attrs += fmt.Sprintf(", style=filled, fillcolor=%q, color=%q", missingFill, missingStroke)
+		}
+		fmt.Fprintf(buf, "%s%s [label=%s%s];\n", indent, v.id, dotQuote(strings.Join(v.label, "\n")), attrs)
+	}
+
+	for _, v := range g.switches {
+		writeVertex("  ", v, ", shape=ellipse")
+	}
+	for _, v := range g.blockLeaves("") {
+		writeVertex("  ", v, "")
+	}
+	for _, c := range g.blocks {
+		fmt.Fprintf(buf, "  subgraph cluster_%s {\n    label=%s;\n    style=dashed;\n", c.id, dotQuote(c.label))
+		for _, v := range g.blockLeaves(c.id) {
+			writeVertex("    ", v, "")
+		}
+		buf.WriteString("  }\n")
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(buf, "  %s -> %s;\n", e.from, e.to)
+	}
+	buf.WriteString("}\n")
+
+	return buf.Bytes()
+}
+
+// toMermaid renders the graph as a Mermaid flowchart
+func (g *graph) toMermaid() []byte {
+	buf := &bytes.Buffer{}
+	for _, h := range g.header {
+		fmt.Fprintf(buf, "%%%% %s: %s\n", h[0], h[1])
+	}
+	fmt.Fprintf(buf, "flowchart TD\n  classDef missing fill:%s,stroke:%s\n", missingFill, missingStroke)
+
+	writeVertex := func(indent string, v *vertex, left, right string) {
+		fmt.Fprintf(buf, "%s%s%s%s%s", indent, v.id, left, mermaidQuote(v.label), right)
+		if v.missing {
+			buf.WriteString(":::missing")
+		}
+		buf.WriteString("\n")
+	}
+
+	for _, v := range g.switches {
+		writeVertex("  ", v, "{{", "}}")
+	}
+	for _, v := range g.blockLeaves("") {
+		writeVertex("  ", v, "[", "]")
+	}
+	for _, c := range g.blocks {
+		fmt.Fprintf(buf, "  subgraph %s[%s]\n", c.id, mermaidQuote([]string{c.label}))
+		for _, v := range g.blockLeaves(c.id) {
+			writeVertex("    ", v, "[", "]")
+		}
+		buf.WriteString("  end\n")
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(buf, "  %s --> %s\n", e.from, e.to)
+	}
+
+	return buf.Bytes()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+func mermaidQuote(lines []string) string {
+	s := strings.Join(lines, "<br>")
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func getTestGraph() *topology.Vertex {
+	return &topology.Vertex{
+		Metadata: map[string]string{topology.KeyGeneratedAt: "2026-01-01T00:00:00Z"},
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"spine": {
+						ID: "spine",
+						Vertices: map[string]*topology.Vertex{
+							"leaf1": {
+								ID:   "leaf1",
+								Name: "Leaf 1",
+								Vertices: map[string]*topology.Vertex{
+									"i1": {ID: "i1", Name: "node1"},
+									"i2": {ID: "i2", Name: "node2"},
+								},
+							},
+							"leaf2": {
+								ID:       "leaf2",
+								Vertices: map[string]*topology.Vertex{"i3": {ID: "i3", Name: "node3"}},
+							},
+						},
+					},
+					topology.NoTopology: {
+						ID:       topology.NoTopology,
+						Vertices: map[string]*topology.Vertex{"i4": {ID: "i4", Name: "node4"}},
+					},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"nvl1": {
+						ID:   "block001",
+						Name: "nvl1",
+						Vertices: map[string]*topology.Vertex{
+							"node1": {ID: "i1", Name: "node1"},
+							"node2": {ID: "i2", Name: "node2"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestToDOT(t *testing.T) {
+	testCases := []struct {
+		name     string
+		collapse bool
+		dot      string
+	}{
+		{
+			name: "Case 1: all nodes",
+			dot: `// generated_at: 2026-01-01T00:00:00Z
+digraph topology {
+  node [shape=box];
+  s1 [label="spine", shape=ellipse];
+  s2 [label="leaf1\nLeaf 1", shape=ellipse];
+  s3 [label="leaf2", shape=ellipse];
+  n1 [label="node4\ni4", style=filled, fillcolor="#f4cccc", color="#cc0000"];
+  n4 [label="node3\ni3"];
+  subgraph cluster_b1 {
+    label="block001 (nvl1)";
+    style=dashed;
+    n2 [label="node1\ni1"];
+    n3 [label="node2\ni2"];
+  }
+  s1 -> s2;
+  s1 -> s3;
+  s2 -> n2;
+  s2 -> n3;
+  s3 -> n4;
+}
+`,
+		},
+		{
+			name:     "Case 2: collapsed leaves",
+			collapse: true,
+			dot: `// generated_at: 2026-01-01T00:00:00Z
+digraph topology {
+  node [shape=box];
+  s1 [label="spine", shape=ellipse];
+  s2 [label="leaf1\nLeaf 1", shape=ellipse];
+  s3 [label="leaf2", shape=ellipse];
+  n1 [label="1 node", style=filled, fillcolor="#f4cccc", color="#cc0000"];
+  n3 [label="1 node"];
+  subgraph cluster_b1 {
+    label="block001 (nvl1)";
+    style=dashed;
+    n2 [label="2 nodes"];
+  }
+  s1 -> s2;
+  s1 -> s3;
+  s2 -> n2;
+  s3 -> n3;
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.dot, string(newGraph(getTestGraph(), tc.collapse).toDOT()))
+		})
+	}
+}
+
+func TestToMermaid(t *testing.T) {
+	expected := `%% generated_at: 2026-01-01T00:00:00Z
+flowchart TD
+  classDef missing fill:#f4cccc,stroke:#cc0000
+  s1{{"spine"}}
+  s2{{"leaf1<br>Leaf 1"}}
+  s3{{"leaf2"}}
+  n1["node4<br>i4"]:::missing
+  n4["node3<br>i3"]
+  subgraph b1["block001 (nvl1)"]
+    n2["node1<br>i1"]
+    n3["node2<br>i2"]
+  end
+  s1 --> s2
+  s1 --> s3
+  s2 --> n2
+  s2 --> n3
+  s3 --> n4
+`
+	require.Equal(t, expected, string(newGraph(getTestGraph(), false).toMermaid()))
+}
+
+func TestBlocksOnly(t *testing.T) {
+	root := getTestGraph()
+	delete(root.Vertices, topology.TopologyTree)
+	root.Metadata = nil
+
+	expected := `digraph topology {
+  node [shape=box];
+  subgraph cluster_b1 {
+    label="block001 (nvl1)";
+    style=dashed;
+    n1 [label="node1\ni1"];
+    n2 [label="node2\ni2"];
+  }
+}
+`
+	require.Equal(t, expected, string(newGraph(root, false).toDOT()))
+}
+
+func TestQuote(t *testing.T) {
+	require.Equal(t, `"a\\b \"c\"\nd"`, dotQuote("a\\b \"c\"\nd"))
+	require.Equal(t, `"a #quot;b#quot;<br>c"`, mermaidQuote([]string{`a "b"`, "c"}))
+}
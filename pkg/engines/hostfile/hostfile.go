@@ -0,0 +1,147 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const (
+	NAME = "hostfile"
+
+	FormatPlain   = "plain"
+	FormatOpenMPI = "openmpi"
+	FormatMPICH   = "mpich"
+)
+
+type HostfileEngine struct{}
+
+type Params struct {
+	// TopoConfigPath (optional) specifies the file path for the hostfile;
+	// if omitted, the hostfile is returned in the response
+	TopoConfigPath string `mapstructure:"topologyConfigPath"`
+	// Format (optional) specifies the hostfile format: "plain" (default) lists the hostnames,
+	// "openmpi" adds "slots=<slots>", and "mpich" adds ":<slots>"
+	Format string `mapstructure:"format"`
+	// Slots (optional) specifies the number of slots per node for the "openmpi" and "mpich" formats
+	Slots int `mapstructure:"slots"`
+	// NodeCount (optional) selects the subset of nodes that uses the fewest switches
+	NodeCount int `mapstructure:"nodeCount"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	if _, err := getParams(cfg.Params); err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &HostfileEngine{}, nil
+}
+
+func getParams(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	switch p.Format {
+	case "":
+		p.Format = FormatPlain
+	case FormatPlain, FormatOpenMPI, FormatMPICH:
+	default:
+		return nil, fmt.Errorf("unsupported format %q", p.Format)
+	}
+
+	if p.Slots < 0 {
+		return nil, fmt.Errorf("invalid slots %d", p.Slots)
+	}
+	if p.Slots == 0 {
+		p.Slots = 1
+	}
+
+	if p.NodeCount < 0 {
+		return nil, fmt.Errorf("invalid nodeCount %d", p.NodeCount)
+	}
+
+	return p, nil
+}
+
+// GetComputeInstances is not supported: the nodes are provided in the request
+func (eng *HostfileEngine) GetComputeInstances(_ context.Context, _ engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, engines.ErrNodesRequired(NAME)
+}
+
+func (eng *HostfileEngine) GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	return GenerateOutput(ctx, root, params)
+}
+
+func GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	p, err := getParams(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return GenerateOutputParams(ctx, root, p)
+}
+
+// GenerateOutputParams generates the hostfile listing the nodes in network locality order
+func GenerateOutputParams(_ context.Context, root *topology.Vertex, params *Params) ([]byte, *httperr.Error) {
+	cfg := &translate.Config{Plugin: topology.TopologyTree}
+	if _, ok := root.Vertices[topology.TopologyTree]; !ok {
+		if _, ok := root.Vertices[topology.TopologyBlock]; !ok {
+			return nil, httperr.NewError(http.StatusBadRequest, "missing tree and block topology")
+		}
+		cfg.Plugin = topology.TopologyBlock
+	}
+	nt, err := translate.NewNetworkTopology(root, cfg)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	nodes, err := nt.LocalityOrder(params.NodeCount)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	data := formatHostfile(nodes, params)
+
+	path := params.TopoConfigPath
+	if len(path) == 0 {
+		klog.Infof("Returning hostfile with %d nodes", len(nodes))
+		return data, nil
+	}
+
+	return engines.WriteOutput(NAME, path, data, nil)
+}
+
+func formatHostfile(nodes []string, params *Params) []byte {
+	buf := &bytes.Buffer{}
+	for _, node := range nodes {
+		switch params.Format {
+		case FormatOpenMPI:
+			fmt.Fprintf(buf, "%s slots=%d\n", node, params.Slots)
+		case FormatMPICH:
+			fmt.Fprintf(buf, "%s:%d\n", node, params.Slots)
+		default:
+			fmt.Fprintln(buf, node)
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestGetParams(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		p      *Params
+		err    string
+	}{
+		{
+			name: "Case 1: defaults",
+			p:    &Params{Format: FormatPlain, Slots: 1},
+		},
+		{
+			name:   "Case 2: all parameters",
+			params: map[string]any{"topologyConfigPath": "/etc/hostfile", "format": "openmpi", "slots": 8, "nodeCount": 4},
+			p:      &Params{TopoConfigPath: "/etc/hostfile", Format: FormatOpenMPI, Slots: 8, NodeCount: 4},
+		},
+		{
+			name:   "Case 3: bad format",
+			params: map[string]any{"format": "rankfile"},
+			err:    `unsupported format "rankfile"`,
+		},
+		{
+			name:   "Case 4: bad slots",
+			params: map[string]any{"slots": -1},
+			err:    "invalid slots -1",
+		},
+		{
+			name:   "Case 5: bad node count",
+			params: map[string]any{"nodeCount": -1},
+			err:    "invalid nodeCount -1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := getParams(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.p, p)
+			}
+		})
+	}
+}
+
+func TestGenerateOutput(t *testing.T) {
+	ctx := context.TODO()
+	root, _ := translate.GetBlockWithMultiIBTestSet()
+
+	testCases := []struct {
+		name   string
+		params map[string]any
+		out    string
+		err    string
+	}{
+		{
+			name: "Case 1: plain",
+			out: `Node301
+Node302
+Node303
+Node401
+Node402
+Node403
+Node104
+Node105
+Node106
+Node201
+Node202
+Node205
+`,
+		},
+		{
+			name:   "Case 2: openmpi subset",
+			params: map[string]any{"format": "openmpi", "slots": 8, "nodeCount": 4},
+			out: `Node301 slots=8
+Node302 slots=8
+Node303 slots=8
+Node401 slots=8
+`,
+		},
+		{
+			name:   "Case 3: mpich subset",
+			params: map[string]any{"format": "mpich", "nodeCount": 2},
+			out: `Node301:1
+Node302:1
+`,
+		},
+		{
+			name:   "Case 4: too many nodes",
+			params: map[string]any{"nodeCount": 20},
+			err:    "requested 20 nodes, but the topology has 12 nodes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := GenerateOutput(ctx, root, tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.out, string(data))
+			}
+		})
+	}
+}
+
+func TestHostfileEngine(t *testing.T) {
+	ctx := context.TODO()
+
+	_, httpErr := Loader(ctx, engines.Config{Params: map[string]any{"format": "rankfile"}})
+	require.EqualError(t, httpErr, `unsupported format "rankfile"`)
+
+	eng, httpErr := Loader(ctx, engines.Config{})
+	require.Nil(t, httpErr)
+
+	_, httpErr = eng.GetComputeInstances(ctx, nil)
+	require.EqualError(t, httpErr, "hostfile engine requires the nodes in the request")
+
+	_, httpErr = eng.GenerateOutput(ctx, &topology.Vertex{}, nil)
+	require.EqualError(t, httpErr, "missing tree and block topology")
+
+	root, _ := translate.GetTreeTestSet(false)
+	path := filepath.Join(t.TempDir(), "hostfile")
+	params := map[string]any{"topologyConfigPath": path}
+
+	data, httpErr := eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(data))
+
+	hostfile, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "Node201\nNode202\nNode205\nNode304\nNode305\nNode306\n", string(hostfile))
+
+	data, httpErr = eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, engines.UnchangedResult, string(data))
+}
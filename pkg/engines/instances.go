@@ -0,0 +1,77 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package engines
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+type instanceMapper interface {
+	// Instances2NodeMap receives a list of node names and returns a map of
+	// the service provider assigned compute instance IDs to the node names
+	Instances2NodeMap(context.Context, []string) (map[string]string, error)
+	// GetInstancesRegions receives a list of node names and returns a map
+	// of node names to their deployed regions
+	GetInstancesRegions(context.Context, []string) (map[string]string, error)
+}
+
+// MapComputeInstances maps the node names to the compute instances with the environment,
+// which must implement instanceMapper. The compute instances are sorted by region.
+func MapComputeInstances(ctx context.Context, environment Environment, nodes []string) ([]topology.ComputeInstances, *httperr.Error) {
+	instanceMapper, ok := environment.(instanceMapper)
+	if !ok {
+		return nil, httperr.NewError(http.StatusBadRequest, "environment must implement instanceMapper")
+	}
+
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	i2n, err := instanceMapper.Instances2NodeMap(ctx, nodes)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+	klog.V(4).Infof("Detected instance map: %v", i2n)
+
+	nodeRegions, err := instanceMapper.GetInstancesRegions(ctx, nodes)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return aggregateComputeInstances(i2n, nodeRegions), nil
+}
+
+func aggregateComputeInstances(i2n, nodeRegions map[string]string) []topology.ComputeInstances {
+	regions := make(map[string]map[string]string)
+	for instance, node := range i2n {
+		region, ok := nodeRegions[node]
+		if !ok {
+			klog.Warningf("Failed to find region for node %s", node)
+			continue
+		}
+		if _, ok := regions[region]; !ok {
+			regions[region] = make(map[string]string)
+		}
+		regions[region][instance] = node
+	}
+
+	names := slices.Sorted(maps.Keys(regions))
+	cis := make([]topology.ComputeInstances, 0, len(names))
+	for _, region := range names {
+		cis = append(cis, topology.ComputeInstances{Region: region, Instances: regions[region]})
+	}
+	klog.V(4).Infof("Detected regions: %v", names)
+
+	return cis
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package json
+
+import (
+	encjson "encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// SchemaVersion is the version of the topology document schema
+const SchemaVersion = "v1"
+
+// Document is the topology document
+type Document struct {
+	Version string `json:"version"`
+	// Metadata holds the topology metadata, such as "generated_at"
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Warnings lists the degradations of the topology, such as failed regions
+	Warnings []topology.Warning `json:"warnings,omitempty"`
+	Nodes    []*Node            `json:"nodes"`
+	Switches []*Switch          `json:"switches"`
+	Blocks   []*Block           `json:"blocks"`
+}
+
+// Node is a compute node
+type Node struct {
+	Name       string `json:"name"`
+	InstanceID string `json:"instanceId"`
+	// Switches lists the switch IDs from the leaf switch up; empty for nodes without topology
+	Switches []string `json:"switches"`
+	// Block is the ID of the NVLink block
+	Block string `json:"block,omitempty"`
+}
+
+// Switch is a network switch with its child switches and nodes
+type Switch struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Parent string `json:"parent,omitempty"`
+	// Switches lists the IDs of the child switches
+	Switches []string `json:"switches,omitempty"`
+	// Nodes lists the names of the connected nodes
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// Block is an NVLink block
+type Block struct {
+	ID string `json:"id"`
+	// Name is the NVLink domain
+	Name  string   `json:"name,omitempty"`
+	Nodes []string `json:"nodes"`
+}
+
+// ToDocument converts the topology into the topology document.
+// Nodes, switches and blocks are sorted by name or ID.
+func ToDocument(root *topology.Vertex) *Document {
+	doc := &Document{
+		Version:  SchemaVersion,
+		Nodes:    []*Node{},
+		Switches: []*Switch{},
+		Blocks:   []*Block{},
+	}
+	if len(root.Metadata) != 0 {
+		doc.Metadata = maps.Clone(root.Metadata)
+	}
+	if len(root.Warnings) != 0 {
+		doc.Warnings = slices.Clone(root.Warnings)
+	}
+
+	nodes := make(map[string]*Node)
+	getNode := func(v *topology.Vertex) *Node {
+		node, ok := nodes[v.Name]
+		if !ok {
+			node = &Node{Name: v.Name, InstanceID: v.ID, Switches: []string{}}
+			nodes[v.Name] = node
+		}
+		return node
+	}
+
+	var addSwitch func(v *topology.Vertex, parent string, path []string)
+	addSwitch = func(v *topology.Vertex, parent string, path []string) {
+		sw := &Switch{ID: v.ID, Name: v.Name, Parent: parent}
+		doc.Switches = append(doc.Switches, sw)
+		path = append([]string{v.ID}, path...)
+		for _, key := range slices.Sorted(maps.Keys(v.Vertices)) {
+			w := v.Vertices[key]
+			if len(w.Vertices) != 0 {
+				sw.Switches = append(sw.Switches, w.ID)
+				addSwitch(w, v.ID, path)
+			} else {
+				sw.Nodes = append(sw.Nodes, w.Name)
+				getNode(w).Switches = path
+			}
+		}
+		slices.Sort(sw.Nodes)
+	}
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok {
+		for _, key := range slices.Sorted(maps.Keys(tree.Vertices)) {
+			v := tree.Vertices[key]
+			if v.ID != topology.NoTopology {
+				addSwitch(v, "", nil)
+				continue
+			}
+			for _, w := range v.Vertices {
+				getNode(w)
+			}
+		}
+	}
+
+	if blocks, ok := root.Vertices[topology.TopologyBlock]; ok {
+		for _, key := range slices.Sorted(maps.Keys(blocks.Vertices)) {
+			v := blocks.Vertices[key]
+			block := &Block{ID: v.ID, Name: v.Name, Nodes: []string{}}
+			for _, w := range v.Vertices {
+				getNode(w).Block = v.ID
+				block.Nodes = append(block.Nodes, w.Name)
+			}
+			slices.Sort(block.Nodes)
+			doc.Blocks = append(doc.Blocks, block)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(nodes)) {
+		doc.Nodes = append(doc.Nodes, nodes[name])
+	}
+	slices.SortFunc(doc.Switches, func(a, b *Switch) int { return strings.Compare(a.ID, b.ID) })
+
+	return doc
+}
+
+// Marshal returns the indented JSON document with a trailing newline
+func (doc *Document) Marshal() ([]byte, error) {
+	data, err := encjson.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal topology document: %v", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// ParseDocument decodes the topology document and checks its version
+func ParseDocument(data []byte) (*Document, error) {
+	doc := &Document{}
+	if err := encjson.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse topology document: %v", err)
+	}
+	if doc.Version != SchemaVersion {
+		return nil, fmt.Errorf("unsupported topology document version %q", doc.Version)
+	}
+	return doc, nil
+}
+
+// ToGraph converts the topology document into the topology graph. The switch hierarchy
+// is taken from the switch parents, and the nodes are connected to their leaf switches,
+// the first ones in the node switch lists. Nodes without switches are placed
+// under the "no-topology" switch if the document has switches.
+func (doc *Document) ToGraph() (*topology.Vertex, error) {
+	root := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+	if len(doc.Metadata) != 0 {
+		root.Metadata = maps.Clone(doc.Metadata)
+	}
+	if len(doc.Warnings) != 0 {
+		root.Warnings = slices.Clone(doc.Warnings)
+	}
+
+	instances := make(map[string]string)
+	for _, node := range doc.Nodes {
+		if len(node.Name) == 0 {
+			return nil, fmt.Errorf("missing node name")
+		}
+		if _, ok := instances[node.Name]; ok {
+			return nil, fmt.Errorf("duplicate node %q", node.Name)
+		}
+		instances[node.Name] = node.InstanceID
+		if len(node.InstanceID) == 0 {
+			instances[node.Name] = node.Name
+		}
+	}
+
+	if len(doc.Switches) != 0 {
+		tree := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		switches := make(map[string]*topology.Vertex)
+		for _, sw := range doc.Switches {
+			if len(sw.ID) == 0 {
+				return nil, fmt.Errorf("missing switch ID")
+			}
+			if _, ok := switches[sw.ID]; ok {
+				return nil, fmt.Errorf("duplicate switch %q", sw.ID)
+			}
+			switches[sw.ID] = &topology.Vertex{ID: sw.ID, Name: sw.Name, Vertices: make(map[string]*topology.Vertex)}
+		}
+
+		for _, sw := range doc.Switches {
+			if len(sw.Parent) == 0 {
+				tree.Vertices[sw.ID] = switches[sw.ID]
+				continue
+			}
+			parent, ok := switches[sw.Parent]
+			if !ok {
+				return nil, fmt.Errorf("unknown parent switch %q of switch %q", sw.Parent, sw.ID)
+			}
+			parent.Vertices[sw.ID] = switches[sw.ID]
+		}
+
+		for _, node := range doc.Nodes {
+			v := &topology.Vertex{ID: instances[node.Name], Name: node.Name}
+			if len(node.Switches) == 0 {
+				sw, ok := tree.Vertices[topology.NoTopology]
+				if !ok {
+					sw = &topology.Vertex{ID: topology.NoTopology, Vertices: make(map[string]*topology.Vertex)}
+					tree.Vertices[topology.NoTopology] = sw
+				}
+				sw.Vertices[v.ID] = v
+				continue
+			}
+			sw, ok := switches[node.Switches[0]]
+			if !ok {
+				return nil, fmt.Errorf("unknown switch %q of node %q", node.Switches[0], node.Name)
+			}
+			sw.Vertices[v.ID] = v
+		}
+
+		root.Vertices[topology.TopologyTree] = tree
+	}
+
+	if len(doc.Blocks) != 0 {
+		blockRoot := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		for _, block := range doc.Blocks {
+			domain := block.Name
+			if len(domain) == 0 {
+				domain = block.ID
+			}
+			v := &topology.Vertex{ID: block.ID, Name: block.Name, Vertices: make(map[string]*topology.Vertex)}
+			for _, name := range block.Nodes {
+				instance, ok := instances[name]
+				if !ok {
+					instance = name
+				}
+				v.Vertices[name] = &topology.Vertex{ID: instance, Name: name}
+			}
+			blockRoot.Vertices[domain] = v
+		}
+		root.Vertices[topology.TopologyBlock] = blockRoot
+	}
+
+	return root, nil
+}
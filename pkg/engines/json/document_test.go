@@ -0,0 +1,217 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const testDocument = `{
+  "version": "v1",
+  "metadata": {
+    "generated_at": "2026-01-01T00:00:00Z"
+  },
+  "nodes": [
+    {
+      "name": "node1",
+      "instanceId": "i1",
+      "switches": [
+        "leaf1",
+        "spine"
+      ],
+      "block": "block001"
+    },
+    {
+      "name": "node2",
+      "instanceId": "i2",
+      "switches": [
+        "leaf1",
+        "spine"
+      ],
+      "block": "block001"
+    },
+    {
+      "name": "node3",
+      "instanceId": "i3",
+      "switches": [
+        "leaf2",
+        "spine"
+      ]
+    },
+    {
+      "name": "node4",
+      "instanceId": "i4",
+      "switches": []
+    }
+  ],
+  "switches": [
+    {
+      "id": "leaf1",
+      "name": "Leaf 1",
+      "parent": "spine",
+      "nodes": [
+        "node1",
+        "node2"
+      ]
+    },
+    {
+      "id": "leaf2",
+      "parent": "spine",
+      "nodes": [
+        "node3"
+      ]
+    },
+    {
+      "id": "spine",
+      "switches": [
+        "leaf1",
+        "leaf2"
+      ]
+    }
+  ],
+  "blocks": [
+    {
+      "id": "block001",
+      "name": "nvl1",
+      "nodes": [
+        "node1",
+        "node2"
+      ]
+    }
+  ]
+}
+`
+
+func getTestGraph() *topology.Vertex {
+	return &topology.Vertex{
+		Metadata: map[string]string{topology.KeyGeneratedAt: "2026-01-01T00:00:00Z"},
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"spine": {
+						ID: "spine",
+						Vertices: map[string]*topology.Vertex{
+							"leaf1": {
+								ID:   "leaf1",
+								Name: "Leaf 1",
+								Vertices: map[string]*topology.Vertex{
+									"i1": {ID: "i1", Name: "node1"},
+									"i2": {ID: "i2", Name: "node2"},
+								},
+							},
+							"leaf2": {
+								ID:       "leaf2",
+								Vertices: map[string]*topology.Vertex{"i3": {ID: "i3", Name: "node3"}},
+							},
+						},
+					},
+					topology.NoTopology: {
+						ID:       topology.NoTopology,
+						Vertices: map[string]*topology.Vertex{"i4": {ID: "i4", Name: "node4"}},
+					},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"nvl1": {
+						ID:   "block001",
+						Name: "nvl1",
+						Vertices: map[string]*topology.Vertex{
+							"node1": {ID: "i1", Name: "node1"},
+							"node2": {ID: "i2", Name: "node2"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestToDocument(t *testing.T) {
+	data, err := ToDocument(getTestGraph()).Marshal()
+	require.NoError(t, err)
+	require.Equal(t, testDocument, string(data))
+}
+
+func TestToDocumentBlocksOnly(t *testing.T) {
+	root := getTestGraph()
+	root.Metadata = nil
+	delete(root.Vertices, topology.TopologyTree)
+
+	require.Equal(t, &Document{
+		Version: SchemaVersion,
+		Nodes: []*Node{
+			{Name: "node1", InstanceID: "i1", Switches: []string{}, Block: "block001"},
+			{Name: "node2", InstanceID: "i2", Switches: []string{}, Block: "block001"},
+		},
+		Switches: []*Switch{},
+		Blocks:   []*Block{{ID: "block001", Name: "nvl1", Nodes: []string{"node1", "node2"}}},
+	}, ToDocument(root))
+}
+
+func TestToGraph(t *testing.T) {
+	doc, err := ParseDocument([]byte(testDocument))
+	require.NoError(t, err)
+	root, err := doc.ToGraph()
+	require.NoError(t, err)
+	require.Equal(t, getTestGraph(), root)
+
+	blocksOnly := getTestGraph()
+	blocksOnly.Metadata = nil
+	delete(blocksOnly.Vertices, topology.TopologyTree)
+	root, err = ToDocument(blocksOnly).ToGraph()
+	require.NoError(t, err)
+	require.Equal(t, blocksOnly, root)
+}
+
+func TestToGraphErrors(t *testing.T) {
+	_, err := ParseDocument([]byte(`{"version":"v0"}`))
+	require.EqualError(t, err, `unsupported topology document version "v0"`)
+
+	_, err = ParseDocument([]byte(`[]`))
+	require.ErrorContains(t, err, "failed to parse topology document")
+
+	testCases := []struct {
+		name string
+		doc  *Document
+		err  string
+	}{
+		{
+			name: "Case 1: duplicate node",
+			doc:  &Document{Nodes: []*Node{{Name: "node1"}, {Name: "node1"}}},
+			err:  `duplicate node "node1"`,
+		},
+		{
+			name: "Case 2: duplicate switch",
+			doc:  &Document{Switches: []*Switch{{ID: "s1"}, {ID: "s1"}}},
+			err:  `duplicate switch "s1"`,
+		},
+		{
+			name: "Case 3: unknown parent switch",
+			doc:  &Document{Switches: []*Switch{{ID: "s1", Parent: "s2"}}},
+			err:  `unknown parent switch "s2" of switch "s1"`,
+		},
+		{
+			name: "Case 4: unknown node switch",
+			doc: &Document{
+				Nodes:    []*Node{{Name: "node1", Switches: []string{"s2"}}},
+				Switches: []*Switch{{ID: "s1"}},
+			},
+			err: `unknown switch "s2" of node "node1"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.doc.ToGraph()
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
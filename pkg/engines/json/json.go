@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package json
+
+import (
+	"bytes"
+	"context"
+	encjson "encoding/json"
+	"maps"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const NAME = "json"
+
+type JSONEngine struct{}
+
+type Params struct {
+	// TopoConfigPath (optional) specifies the file path for the topology document;
+	// if omitted, the document is returned in the response
+	TopoConfigPath string `mapstructure:"topologyConfigPath"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, _ engines.Config) (engines.Engine, *httperr.Error) {
+	return &JSONEngine{}, nil
+}
+
+// GetComputeInstances is not supported: the nodes are provided in the request
+func (eng *JSONEngine) GetComputeInstances(_ context.Context, _ engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, engines.ErrNodesRequired(NAME)
+}
+
+func (eng *JSONEngine) GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	return GenerateOutput(ctx, root, params)
+}
+
+func GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return GenerateOutputParams(ctx, root, p)
+}
+
+// GenerateOutputParams generates the topology document
+func GenerateOutputParams(_ context.Context, root *topology.Vertex, params *Params) ([]byte, *httperr.Error) {
+	doc := ToDocument(root)
+	data, err := doc.Marshal()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	path := params.TopoConfigPath
+	if len(path) == 0 {
+		klog.Info("Returning topology document")
+		return data, nil
+	}
+
+	return engines.WriteOutput(NAME, path, data, func(current, _ []byte) bool {
+		equal, err := equalDocuments(current, doc)
+		return err == nil && equal
+	})
+}
+
+// equalDocuments compares the serialized document with the new one, ignoring the header metadata,
+// such as the "generated_at" timestamp, and the warnings
+func equalDocuments(data []byte, doc *Document) (bool, error) {
+	current := &Document{}
+	if err := encjson.Unmarshal(data, current); err != nil {
+		return false, err
+	}
+	for _, key := range topology.HeaderKeys {
+		delete(current.Metadata, key)
+	}
+	current.Warnings = nil
+	a, err := current.Marshal()
+	if err != nil {
+		return false, err
+	}
+
+	next := *doc
+	next.Metadata = maps.Clone(doc.Metadata)
+	for _, key := range topology.HeaderKeys {
+		delete(next.Metadata, key)
+	}
+	next.Warnings = nil
+	b, err := next.Marshal()
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(a, b), nil
+}
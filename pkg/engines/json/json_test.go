@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package json
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestJSONEngine(t *testing.T) {
+	ctx := context.TODO()
+
+	eng, httpErr := Loader(ctx, engines.Config{})
+	require.Nil(t, httpErr)
+
+	_, httpErr = eng.GetComputeInstances(ctx, nil)
+	require.EqualError(t, httpErr, "json engine requires the nodes in the request")
+
+	data, httpErr := eng.GenerateOutput(ctx, getTestGraph(), nil)
+	require.Nil(t, httpErr)
+	require.Equal(t, testDocument, string(data))
+
+	path := filepath.Join(t.TempDir(), "topology.json")
+	params := map[string]any{"topologyConfigPath": path}
+	data, httpErr = eng.GenerateOutput(ctx, getTestGraph(), params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(data))
+
+	doc, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, testDocument, string(doc))
+
+	// a new generated_at timestamp does not change the document
+	root := getTestGraph()
+	root.Metadata[topology.KeyGeneratedAt] = "2026-01-02T00:00:00Z"
+	data, httpErr = eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, engines.UnchangedResult, string(data))
+
+	delete(root.Vertices, topology.TopologyBlock)
+	data, httpErr = eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(data))
+}
@@ -22,6 +22,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -36,12 +37,16 @@ const NAME = "k8s"
 type K8sEngine struct {
 	config *rest.Config
 	client *kubernetes.Clientset
-	params *Params
+	// dynamic is the client for the Kueue Topology object
+	dynamic dynamic.Interface
+	params  *Params
 }
 
 type Params struct {
 	// NodeSelector (optional) specifies nodes participating in the topology
 	NodeSelector map[string]string `mapstructure:"nodeSelector"`
+	// KueueTopology (optional) creates or updates the Kueue Topology object with the node label levels
+	KueueTopology *KueueTopology `mapstructure:"kueueTopology"`
 
 	// derived fields
 	nodeListOpt *metav1.ListOptions
@@ -51,8 +56,8 @@ func NamedLoader() (string, engines.Loader) {
 	return NAME, Loader
 }
 
-func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
-	p, err := getParameters(params)
+func Loader(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(cfg.Params)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
@@ -67,14 +72,20 @@ func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.
 		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
 	return &K8sEngine{
-		config: config,
-		client: client,
-		params: p,
+		config:  config,
+		client:  client,
+		dynamic: dynamicClient,
+		params:  p,
 	}, nil
 }
 
-func getParameters(params engines.Config) (*Params, error) {
+func getParameters(params map[string]any) (*Params, error) {
 	p := &Params{}
 	if err := config.Decode(params, p); err != nil {
 		return nil, err
@@ -86,6 +97,12 @@ func getParameters(params engines.Config) (*Params, error) {
 		}
 	}
 
+	if p.KueueTopology != nil {
+		if err := p.KueueTopology.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	return p, nil
 }
 
@@ -94,5 +111,11 @@ func (eng *K8sEngine) GenerateOutput(ctx context.Context, tree *topology.Vertex,
 		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
 	}
 
+	if eng.params.KueueTopology != nil {
+		if _, err := ApplyKueueTopology(ctx, eng.dynamic, eng.params.KueueTopology); err != nil {
+			return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+		}
+	}
+
 	return []byte("OK\n"), nil
 }
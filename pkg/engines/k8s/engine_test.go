@@ -39,6 +39,18 @@ func TestGetParameters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "Case 4: Kueue topology",
+			params: map[string]any{"kueueTopology": map[string]any{"levels": []string{"block", "accelerator"}}},
+			ret: &Params{
+				KueueTopology: &KueueTopology{Name: DefaultKueueTopologyName, Levels: []string{LevelBlock, LevelAccelerator}},
+			},
+		},
+		{
+			name:   "Case 5: invalid Kueue topology",
+			params: map[string]any{"kueueTopology": map[string]any{"levels": []string{"rack"}}},
+			err:    `unsupported Kueue topology level "rack"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// Kueue Topology levels
+const (
+	LevelDatacenter  = "datacenter"
+	LevelSpine       = "spine"
+	LevelBlock       = "block"
+	LevelAccelerator = "accelerator"
+	LevelHostname    = "hostname"
+
+	DefaultKueueTopologyName = "default"
+)
+
+var (
+	KueueTopologyGVR = schema.GroupVersionResource{Group: "kueue.x-k8s.io", Version: "v1alpha1", Resource: "topologies"}
+
+	// kueueLevelHierarchy lists the levels from the top of the hierarchy
+	kueueLevelHierarchy = []string{LevelDatacenter, LevelSpine, LevelBlock, LevelAccelerator, LevelHostname}
+
+	defaultKueueLevels = []string{LevelDatacenter, LevelSpine, LevelBlock, LevelAccelerator}
+)
+
+// KueueTopology describes the Kueue Topology object for Topology Aware Scheduling
+type KueueTopology struct {
+	// Name (optional) specifies the name of the Topology object
+	Name string `mapstructure:"name"`
+	// Levels (optional) lists the topology levels: "datacenter", "spine", "block",
+	// "accelerator" and "hostname". Defaults to all levels except "hostname".
+	Levels []string `mapstructure:"levels"`
+}
+
+// validate applies the defaults and orders the levels from the top of the hierarchy
+func (kt *KueueTopology) validate() error {
+	if len(kt.Name) == 0 {
+		kt.Name = DefaultKueueTopologyName
+	}
+
+	if len(kt.Levels) == 0 {
+		kt.Levels = defaultKueueLevels
+		return nil
+	}
+
+	levels := make([]string, 0, len(kt.Levels))
+	for _, level := range kueueLevelHierarchy {
+		if slices.Contains(kt.Levels, level) {
+			levels = append(levels, level)
+		}
+	}
+	for _, level := range kt.Levels {
+		if !slices.Contains(kueueLevelHierarchy, level) {
+			return fmt.Errorf("unsupported Kueue topology level %q", level)
+		}
+	}
+	if len(levels) != len(kt.Levels) {
+		return fmt.Errorf("duplicate Kueue topology levels %v", kt.Levels)
+	}
+	kt.Levels = levels
+
+	return nil
+}
+
+// nodeLabels returns the node labels of the levels set by InitLabels
+func (kt *KueueTopology) nodeLabels() []string {
+	labels := make([]string, 0, len(kt.Levels))
+	for _, level := range kt.Levels {
+		switch level {
+		case LevelDatacenter:
+			labels = append(labels, labelDatacenter)
+		case LevelSpine:
+			labels = append(labels, labelSpine)
+		case LevelBlock:
+			labels = append(labels, labelBlock)
+		case LevelAccelerator:
+			labels = append(labels, labelAccelerator)
+		case LevelHostname:
+			labels = append(labels, corev1.LabelHostname)
+		}
+	}
+	return labels
+}
+
+// ApplyKueueTopology creates or updates the Kueue Topology object.
+// It returns false if the object already lists the same levels.
+func ApplyKueueTopology(ctx context.Context, client dynamic.Interface, kt *KueueTopology) (bool, error) {
+	labels := kt.nodeLabels()
+	levels := make([]any, 0, len(labels))
+	for _, label := range labels {
+		levels = append(levels, map[string]any{"nodeLabel": label})
+	}
+	annotations := map[string]string{
+		topology.KeyConfigMapEngine:            NAME,
+		topology.KeyConfigMapTopologyManagedBy: "topograph",
+		topology.KeyConfigMapLastUpdated:       time.Now().Format(time.RFC3339),
+	}
+
+	res := client.Resource(KueueTopologyGVR)
+	obj, err := res.Get(ctx, kt.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		obj = &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": KueueTopologyGVR.GroupVersion().String(),
+			"kind":       "Topology",
+			"metadata":   map[string]any{"name": kt.Name},
+		}}
+		obj.SetAnnotations(annotations)
+		if err = unstructured.SetNestedSlice(obj.Object, levels, "spec", "levels"); err != nil {
+			return false, err
+		}
+		if _, err = res.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			return false, fmt.Errorf("failed to create Kueue topology %s: %v", kt.Name, err)
+		}
+		klog.Infof("Created Kueue topology %s with levels %v", kt.Name, labels)
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get Kueue topology %s: %v", kt.Name, err)
+	}
+
+	if slices.Equal(getKueueLevels(obj), labels) {
+		klog.Infof("Kueue topology %s is unchanged", kt.Name)
+		return false, nil
+	}
+
+	current := obj.GetAnnotations()
+	if current == nil {
+		current = make(map[string]string)
+	}
+	maps.Copy(current, annotations)
+	obj.SetAnnotations(current)
+	if err = unstructured.SetNestedSlice(obj.Object, levels, "spec", "levels"); err != nil {
+		return false, err
+	}
+	if _, err = res.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return false, fmt.Errorf("failed to update Kueue topology %s: %v", kt.Name, err)
+	}
+	klog.Infof("Updated Kueue topology %s with levels %v", kt.Name, labels)
+
+	return true, nil
+}
+
+// getKueueLevels returns the node labels of the Topology object levels
+func getKueueLevels(obj *unstructured.Unstructured) []string {
+	levels, _, _ := unstructured.NestedSlice(obj.Object, "spec", "levels")
+	labels := make([]string, 0, len(levels))
+	for _, level := range levels {
+		if m, ok := level.(map[string]any); ok {
+			label, _ := m["nodeLabel"].(string)
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
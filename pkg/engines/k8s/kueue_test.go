@@ -0,0 +1,103 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestKueueTopologyValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		topology *KueueTopology
+		expected *KueueTopology
+		err      string
+	}{
+		{
+			name:     "Case 1: defaults",
+			topology: &KueueTopology{},
+			expected: &KueueTopology{
+				Name:   DefaultKueueTopologyName,
+				Levels: []string{LevelDatacenter, LevelSpine, LevelBlock, LevelAccelerator},
+			},
+		},
+		{
+			name:     "Case 2: level subset",
+			topology: &KueueTopology{Name: "tas", Levels: []string{LevelHostname, LevelBlock, LevelSpine}},
+			expected: &KueueTopology{Name: "tas", Levels: []string{LevelSpine, LevelBlock, LevelHostname}},
+		},
+		{
+			name:     "Case 3: unsupported level",
+			topology: &KueueTopology{Levels: []string{LevelBlock, "rack"}},
+			err:      `unsupported Kueue topology level "rack"`,
+		},
+		{
+			name:     "Case 4: duplicate level",
+			topology: &KueueTopology{Levels: []string{LevelBlock, LevelBlock}},
+			err:      "duplicate Kueue topology levels [block block]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.topology.validate()
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, tc.topology)
+			}
+		})
+	}
+}
+
+func TestApplyKueueTopology(t *testing.T) {
+	ctx := context.TODO()
+	InitLabels(DefaultLabelAccelerator, DefaultLabelBlock, DefaultLabelSpine, DefaultLabelDatacenter)
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{KueueTopologyGVR: "TopologyList"})
+
+	kt := &KueueTopology{}
+	require.NoError(t, kt.validate())
+
+	// create
+	changed, err := ApplyKueueTopology(ctx, client, kt)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	obj, err := client.Resource(KueueTopologyGVR).Get(ctx, DefaultKueueTopologyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "Topology", obj.GetKind())
+	require.Equal(t, "kueue.x-k8s.io/v1alpha1", obj.GetAPIVersion())
+	require.Equal(t, "topograph", obj.GetAnnotations()[topology.KeyConfigMapTopologyManagedBy])
+	require.Equal(t, []string{DefaultLabelDatacenter, DefaultLabelSpine, DefaultLabelBlock, DefaultLabelAccelerator}, getKueueLevels(obj))
+
+	// unchanged
+	changed, err = ApplyKueueTopology(ctx, client, kt)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// update
+	kt = &KueueTopology{Levels: []string{LevelBlock, LevelHostname}}
+	require.NoError(t, kt.validate())
+	changed, err = ApplyKueueTopology(ctx, client, kt)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	obj, err = client.Resource(KueueTopologyGVR).Get(ctx, DefaultKueueTopologyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{DefaultLabelBlock, "kubernetes.io/hostname"}, getKueueLevels(obj))
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package engines
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+)
+
+// WriteOutput replaces the file with the generated output of the engine, and returns "OK".
+// If the file content equals the output, the file is not rewritten and UnchangedResult is returned.
+// The equal function compares the file content with the output; bytes.Equal is used if nil.
+func WriteOutput(engine, path string, data []byte, equal func(current, data []byte) bool) ([]byte, *httperr.Error) {
+	if equal == nil {
+		equal = bytes.Equal
+	}
+
+	if current, err := os.ReadFile(path); err == nil && equal(current, data) {
+		klog.Infof("Output of engine %s in %q is unchanged", engine, path)
+		metrics.AddUnchangedTopology(engine)
+		return []byte(UnchangedResult), nil
+	}
+
+	klog.Infof("Writing output of engine %s in %q", engine, path)
+	if err := files.CreateAtomic(path, data); err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return []byte("OK\n"), nil
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package engines
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topology.conf")
+
+	out, err := WriteOutput("test", path, []byte("data\n"), nil)
+	require.Nil(t, err)
+	require.Equal(t, "OK\n", string(out))
+	data, _ := os.ReadFile(path)
+	require.Equal(t, "data\n", string(data))
+
+	out, err = WriteOutput("test", path, []byte("data\n"), nil)
+	require.Nil(t, err)
+	require.Equal(t, UnchangedResult, string(out))
+
+	// the custom comparison ignores the case; the file is not rewritten
+	equalFold := func(current, data []byte) bool { return bytes.EqualFold(current, data) }
+	out, err = WriteOutput("test", path, []byte("DATA\n"), equalFold)
+	require.Nil(t, err)
+	require.Equal(t, UnchangedResult, string(out))
+	data, _ = os.ReadFile(path)
+	require.Equal(t, "data\n", string(data))
+
+	out, err = WriteOutput("test", path, []byte("other\n"), equalFold)
+	require.Nil(t, err)
+	require.Equal(t, "OK\n", string(out))
+	data, _ = os.ReadFile(path)
+	require.Equal(t, "other\n", string(data))
+
+	_, err = WriteOutput("test", filepath.Join(path, "missing", "file"), []byte("data\n"), nil)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusInternalServerError, err.Code())
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pbs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "pbs"
+
+	FormatQmgr   = "qmgr"
+	FormatScript = "script"
+
+	defaultSwitchResource = "switch"
+	defaultBlockResource  = "block"
+)
+
+type PBSEngine struct {
+	params *Params
+	// vnodes lists the discovered vnode names
+	vnodes []string
+}
+
+type Params struct {
+	// TopoConfigPath (optional) specifies the file path for the generated qmgr commands;
+	// if omitted, the commands are returned in the response
+	TopoConfigPath string `mapstructure:"topologyConfigPath"`
+	// Format (optional) specifies the output format: "qmgr" (default) for qmgr input,
+	// or "script" for a shell script
+	Format string `mapstructure:"format"`
+	// Apply (optional) runs the qmgr commands
+	Apply bool `mapstructure:"apply"`
+	// DryRun (optional) returns the qmgr commands that Apply would run without running them
+	DryRun bool `mapstructure:"dryRun"`
+	// SwitchResource (optional) specifies the custom resource for the switch placement sets
+	SwitchResource string `mapstructure:"switchResource"`
+	// BlockResource (optional) specifies the custom resource for the block placement sets
+	BlockResource string `mapstructure:"blockResource"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParams(cfg.Params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &PBSEngine{params: p}, nil
+}
+
+func getParams(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	switch p.Format {
+	case "":
+		p.Format = FormatQmgr
+	case FormatQmgr, FormatScript:
+	default:
+		return nil, fmt.Errorf("unsupported format %q", p.Format)
+	}
+
+	if p.Apply && len(p.TopoConfigPath) != 0 {
+		return nil, fmt.Errorf("apply and topologyConfigPath parameters are mutually exclusive")
+	}
+	if p.DryRun && !p.Apply {
+		return nil, fmt.Errorf("dryRun parameter requires apply")
+	}
+
+	if len(p.SwitchResource) == 0 {
+		p.SwitchResource = defaultSwitchResource
+	}
+	if len(p.BlockResource) == 0 {
+		p.BlockResource = defaultBlockResource
+	}
+	if p.SwitchResource == p.BlockResource {
+		return nil, fmt.Errorf("switchResource and blockResource must differ")
+	}
+
+	return p, nil
+}
+
+func (eng *PBSEngine) GetComputeInstances(ctx context.Context, environment engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	vnodes, err := GetVnodes(ctx)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	eng.vnodes = make([]string, 0, len(vnodes))
+	for _, vnode := range vnodes {
+		eng.vnodes = append(eng.vnodes, vnode.Name)
+	}
+
+	return engines.MapComputeInstances(ctx, environment, eng.vnodes)
+}
+
+func (eng *PBSEngine) GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	p, err := getParams(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return generateOutput(ctx, root, p, eng.vnodes)
+}
+
+func GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	p, err := getParams(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return GenerateOutputParams(ctx, root, p)
+}
+
+// GenerateOutputParams generates the qmgr commands for the vnodes in the topology
+func GenerateOutputParams(ctx context.Context, root *topology.Vertex, params *Params) ([]byte, *httperr.Error) {
+	return generateOutput(ctx, root, params, nil)
+}
+
+func generateOutput(ctx context.Context, root *topology.Vertex, params *Params, known []string) ([]byte, *httperr.Error) {
+	pl := getPlacement(root, params, known)
+
+	if params.Apply {
+		return apply(ctx, pl, params)
+	}
+
+	data := formatCommands(pl.commands(nil, nil), params.Format)
+
+	path := params.TopoConfigPath
+	if len(path) == 0 {
+		klog.Info("Returning qmgr commands")
+		return data, nil
+	}
+
+	return engines.WriteOutput(NAME, path, data, nil)
+}
+
+// apply runs the qmgr commands changing the current vnode resources
+func apply(ctx context.Context, pl *placement, params *Params) ([]byte, *httperr.Error) {
+	vnodes, err := GetVnodes(ctx)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+	current := make(map[string]*Vnode)
+	for _, vnode := range vnodes {
+		current[vnode.Name] = vnode
+	}
+
+	existing := make(map[string]bool)
+	for _, resource := range pl.resources {
+		// "list resource" fails for undefined resources
+		_, err := exec.Exec(ctx, "qmgr", []string{"-c", "list resource " + resource}, nil)
+		existing[resource] = err == nil
+	}
+
+	cmds := pl.commands(current, existing)
+	if len(cmds) == 0 {
+		klog.Info("PBS placement sets are unchanged")
+		metrics.AddUnchangedTopology(NAME)
+		return []byte(engines.UnchangedResult), nil
+	}
+
+	if params.DryRun {
+		klog.Infof("Dry run: returning %d qmgr commands", len(cmds))
+		return formatCommands(cmds, params.Format), nil
+	}
+
+	for _, cmd := range cmds {
+		if _, err := exec.Exec(ctx, "qmgr", []string{"-c", cmd}, nil); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+	klog.Infof("Applied %d qmgr commands", len(cmds))
+
+	return []byte("OK\n"), nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pbs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const testPbsnodes = `node1
+     Mom = node1.cluster
+     ntype = PBS
+     state = free
+     resources_available.host = node1
+     resources_available.switch = leaf1,spine
+     resources_available.vnode = node1
+     resv_enable = True
+
+node2
+     Mom = node2.cluster
+     state = down,offline
+     resources_available.host = node2
+     resources_available.switch = leaf2,spine
+
+node4
+     Mom = node4.cluster
+     state = job-busy
+     resources_available.block = block002
+`
+
+type testInstanceMapper struct{}
+
+func (m *testInstanceMapper) Instances2NodeMap(_ context.Context, nodes []string) (map[string]string, error) {
+	i2n := make(map[string]string)
+	for _, node := range nodes {
+		i2n["i-"+node] = node
+	}
+	return i2n, nil
+}
+
+func (m *testInstanceMapper) GetInstancesRegions(_ context.Context, nodes []string) (map[string]string, error) {
+	regions := make(map[string]string)
+	for _, node := range nodes {
+		regions[node] = "region"
+	}
+	return regions, nil
+}
+
+// fakePBS installs "pbsnodes" and "qmgr" scripts into PATH; qmgr appends its commands
+// to the returned log file and fails "list resource block"
+func fakePBS(t *testing.T) string {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "qmgr.log")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pbsnodes"), []byte("#!/bin/sh\ncat <<'EOF'\n"+testPbsnodes+"EOF\n"), 0755))
+	qmgr := `#!/bin/sh
+if [ "$2" = "list resource block" ]; then exit 1; fi
+echo "$2" >> ` + log + `
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "qmgr"), []byte(qmgr), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func TestParseVnodes(t *testing.T) {
+	vnodes, err := parseVnodes(testPbsnodes)
+	require.NoError(t, err)
+	require.Equal(t, []*Vnode{
+		{Name: "node1", State: "free", Resources: map[string]string{"host": "node1", "switch": "leaf1,spine", "vnode": "node1"}},
+		{Name: "node2", State: "down,offline", Resources: map[string]string{"host": "node2", "switch": "leaf2,spine"}},
+		{Name: "node4", State: "job-busy", Resources: map[string]string{"block": "block002"}},
+	}, vnodes)
+
+	_, err = parseVnodes("     state = free\n")
+	require.EqualError(t, err, `unexpected pbsnodes line "     state = free"`)
+}
+
+func TestGetParams(t *testing.T) {
+	_, err := getParams(map[string]any{"format": "json"})
+	require.EqualError(t, err, `unsupported format "json"`)
+
+	_, err = getParams(map[string]any{"apply": true, "topologyConfigPath": "/tmp/pbs"})
+	require.EqualError(t, err, "apply and topologyConfigPath parameters are mutually exclusive")
+
+	_, err = getParams(map[string]any{"dryRun": true})
+	require.EqualError(t, err, "dryRun parameter requires apply")
+
+	_, err = getParams(map[string]any{"switchResource": "block"})
+	require.EqualError(t, err, "switchResource and blockResource must differ")
+}
+
+func TestPBSEngine(t *testing.T) {
+	ctx := context.TODO()
+	log := fakePBS(t)
+	root := getTestGraph()
+
+	eng, httpErr := Loader(ctx, engines.Config{})
+	require.Nil(t, httpErr)
+
+	cis, httpErr := eng.GetComputeInstances(ctx, &testInstanceMapper{})
+	require.Nil(t, httpErr)
+	require.Equal(t, []topology.ComputeInstances{{
+		Region:    "region",
+		Instances: map[string]string{"i-node1": "node1", "i-node2": "node2", "i-node4": "node4"},
+	}}, cis)
+
+	// write commands to file
+	path := filepath.Join(t.TempDir(), "pbs.sh")
+	params := map[string]any{"topologyConfigPath": path, "format": FormatScript}
+	out, httpErr := eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(out))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `qmgr -c 'set node node3 resources_available.switch = "leaf2,spine"'`)
+	require.Contains(t, string(data), `qmgr -c 'unset node node4 resources_available.switch'`)
+
+	out, httpErr = eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, engines.UnchangedResult, string(out))
+
+	// dry run
+	expected := `create resource block type=string_array, flag=h
+set node node1 resources_available.block = "block001"
+set node node2 resources_available.switch = "leaf1,spine"
+set node node2 resources_available.block = "block001"
+unset node node4 resources_available.block
+set server node_group_key = "switch,block"
+set server node_group_enable = True
+`
+	out, httpErr = eng.GenerateOutput(ctx, root, map[string]any{"apply": true, "dryRun": true})
+	require.Nil(t, httpErr)
+	require.Equal(t, expected, string(out))
+	data, err = os.ReadFile(log)
+	require.NoError(t, err)
+	require.Equal(t, "list resource switch\n", string(data))
+
+	// apply
+	out, httpErr = eng.GenerateOutput(ctx, root, map[string]any{"apply": true})
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(out))
+	data, err = os.ReadFile(log)
+	require.NoError(t, err)
+	require.Equal(t, "list resource switch\nlist resource switch\n"+expected, string(data))
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pbs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+)
+
+// Vnode describes a PBS vnode
+type Vnode struct {
+	Name  string
+	State string
+	// Resources holds the available resources, e.g. "switch" for "resources_available.switch"
+	Resources map[string]string
+}
+
+// GetVnodes returns the PBS vnodes reported by "pbsnodes -av"
+func GetVnodes(ctx context.Context) ([]*Vnode, error) {
+	stdout, err := exec.Exec(ctx, "pbsnodes", []string{"-av"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("stdout: %s", stdout.String())
+
+	return parseVnodes(stdout.String())
+}
+
+// parseVnodes parses "pbsnodes -av" output: every vnode starts with an unindented name line
+// followed by indented "key = value" attributes
+func parseVnodes(data string) ([]*Vnode, error) {
+	vnodes := []*Vnode{}
+	var vnode *Vnode
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		if line[0] != ' ' && line[0] != '\t' {
+			vnode = &Vnode{Name: strings.TrimSpace(line), Resources: make(map[string]string)}
+			vnodes = append(vnodes, vnode)
+			continue
+		}
+
+		if vnode == nil {
+			return nil, fmt.Errorf("unexpected pbsnodes line %q", line)
+		}
+
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if key == "state" {
+			vnode.State = val
+		} else if name, ok := strings.CutPrefix(key, "resources_available."); ok {
+			vnode.Resources[name] = val
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan pbsnodes output: %v", err)
+	}
+
+	return vnodes, nil
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pbs
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const ScriptHeader = `#!/bin/sh
+# PBS placement sets generated from the network topology
+set -e
+`
+
+// placement holds the placement set resources of the vnodes
+type placement struct {
+	// resources lists the custom resources in node_group_key order
+	resources []string
+	// vnodes maps vnode names to resource values; an empty value unsets the resource
+	vnodes map[string]map[string]string
+}
+
+// getPlacement assigns every vnode in the topology a switch resource listing its switches
+// from the leaf up, and a block resource with its block ID. Discovered vnodes without
+// topology data get empty values, so that stale resources are unset.
+func getPlacement(root *topology.Vertex, params *Params, known []string) *placement {
+	pl := &placement{vnodes: make(map[string]map[string]string)}
+	set := func(vnode, resource, value string) {
+		if _, ok := pl.vnodes[vnode]; !ok {
+			pl.vnodes[vnode] = make(map[string]string)
+		}
+		pl.vnodes[vnode][resource] = value
+	}
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok && len(tree.Vertices) != 0 {
+		pl.resources = append(pl.resources, params.SwitchResource)
+		for _, v := range tree.Vertices {
+			if v.ID != topology.NoTopology {
+				addSwitchPath(v, nil, func(vnode, value string) { set(vnode, params.SwitchResource, value) })
+			}
+		}
+	}
+
+	if blocks, ok := root.Vertices[topology.TopologyBlock]; ok && len(blocks.Vertices) != 0 {
+		pl.resources = append(pl.resources, params.BlockResource)
+		for _, block := range blocks.Vertices {
+			for _, w := range block.Vertices {
+				set(w.Name, params.BlockResource, block.ID)
+			}
+		}
+	}
+
+	for _, vnode := range known {
+		for _, resource := range pl.resources {
+			if _, ok := pl.vnodes[vnode][resource]; !ok {
+				set(vnode, resource, "")
+			}
+		}
+	}
+
+	return pl
+}
+
+// addSwitchPath assigns the vnodes of the switch subtree their switch names from the leaf up
+func addSwitchPath(v *topology.Vertex, path []string, set func(string, string)) {
+	name := v.Name
+	if len(name) == 0 {
+		name = v.ID
+	}
+	path = append([]string{name}, path...)
+
+	for _, w := range v.Vertices {
+		if len(w.Vertices) == 0 {
+			set(w.Name, strings.Join(path, ","))
+		} else {
+			addSwitchPath(w, path, set)
+		}
+	}
+}
+
+// commands returns the qmgr commands creating the missing resources and setting
+// the resources of the current vnodes that differ; nil current vnodes
+// and existing resources produce the complete command list
+func (pl *placement) commands(current map[string]*Vnode, existing map[string]bool) []string {
+	cmds := []string{}
+	for _, resource := range pl.resources {
+		if !existing[resource] {
+			cmds = append(cmds, fmt.Sprintf("create resource %s type=string_array, flag=h", resource))
+		}
+	}
+
+	for _, vnode := range slices.Sorted(maps.Keys(pl.vnodes)) {
+		cur, known := current[vnode]
+		if current != nil && !known {
+			continue
+		}
+		for _, resource := range pl.resources {
+			value, ok := pl.vnodes[vnode][resource]
+			if !ok || (known && cur.Resources[resource] == value) {
+				continue
+			}
+			if len(value) != 0 {
+				cmds = append(cmds, fmt.Sprintf("set node %s resources_available.%s = %q", vnode, resource, value))
+			} else {
+				cmds = append(cmds, fmt.Sprintf("unset node %s resources_available.%s", vnode, resource))
+			}
+		}
+	}
+
+	if len(cmds) == 0 {
+		return cmds
+	}
+
+	return append(cmds,
+		fmt.Sprintf("set server node_group_key = %q", strings.Join(pl.resources, ",")),
+		"set server node_group_enable = True")
+}
+
+// formatCommands renders the qmgr commands as qmgr input or as a shell script
+func formatCommands(cmds []string, format string) []byte {
+	buf := &bytes.Buffer{}
+	if format == FormatScript {
+		buf.WriteString(ScriptHeader)
+	}
+	for _, cmd := range cmds {
+		if format == FormatScript {
+			fmt.Fprintf(buf, "qmgr -c '%s'\n", cmd)
+		} else {
+			fmt.Fprintln(buf, cmd)
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pbs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func getTestGraph() *topology.Vertex {
+	return &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"spine": {
+						ID: "spine",
+						Vertices: map[string]*topology.Vertex{
+							"leaf1": {
+								ID: "leaf1",
+								Vertices: map[string]*topology.Vertex{
+									"i1": {ID: "i1", Name: "node1"},
+									"i2": {ID: "i2", Name: "node2"},
+								},
+							},
+							"leaf2": {
+								ID:       "leaf2",
+								Vertices: map[string]*topology.Vertex{"i3": {ID: "i3", Name: "node3"}},
+							},
+						},
+					},
+					topology.NoTopology: {
+						ID:       topology.NoTopology,
+						Vertices: map[string]*topology.Vertex{"i4": {ID: "i4", Name: "node4"}},
+					},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"nvl1": {
+						ID:   "block001",
+						Name: "nvl1",
+						Vertices: map[string]*topology.Vertex{
+							"node1": {ID: "i1", Name: "node1"},
+							"node2": {ID: "i2", Name: "node2"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCommands(t *testing.T) {
+	params, err := getParams(nil)
+	require.NoError(t, err)
+	pl := getPlacement(getTestGraph(), params, []string{"node1", "node4"})
+
+	testCases := []struct {
+		name     string
+		current  map[string]*Vnode
+		existing map[string]bool
+		cmds     []string
+	}{
+		{
+			name: "Case 1: all commands",
+			cmds: []string{
+				"create resource switch type=string_array, flag=h",
+				"create resource block type=string_array, flag=h",
+				`set node node1 resources_available.switch = "leaf1,spine"`,
+				`set node node1 resources_available.block = "block001"`,
+				`set node node2 resources_available.switch = "leaf1,spine"`,
+				`set node node2 resources_available.block = "block001"`,
+				`set node node3 resources_available.switch = "leaf2,spine"`,
+				"unset node node4 resources_available.switch",
+				"unset node node4 resources_available.block",
+				`set server node_group_key = "switch,block"`,
+				"set server node_group_enable = True",
+			},
+		},
+		{
+			name: "Case 2: changed vnodes",
+			current: map[string]*Vnode{
+				"node1": {Name: "node1", Resources: map[string]string{"switch": "leaf1,spine", "block": "block001"}},
+				"node2": {Name: "node2", Resources: map[string]string{"switch": "leaf2,spine", "block": "block001"}},
+				"node3": {Name: "node3", Resources: map[string]string{"switch": "leaf2,spine"}},
+				"node4": {Name: "node4", Resources: map[string]string{"block": "block002"}},
+			},
+			existing: map[string]bool{"switch": true, "block": true},
+			cmds: []string{
+				`set node node2 resources_available.switch = "leaf1,spine"`,
+				"unset node node4 resources_available.block",
+				`set server node_group_key = "switch,block"`,
+				"set server node_group_enable = True",
+			},
+		},
+		{
+			name: "Case 3: unchanged vnodes",
+			current: map[string]*Vnode{
+				"node1": {Name: "node1", Resources: map[string]string{"switch": "leaf1,spine", "block": "block001"}},
+				"node2": {Name: "node2", Resources: map[string]string{"switch": "leaf1,spine", "block": "block001"}},
+				"node3": {Name: "node3", Resources: map[string]string{"switch": "leaf2,spine"}},
+			},
+			existing: map[string]bool{"switch": true, "block": true},
+			cmds:     []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.cmds, pl.commands(tc.current, tc.existing))
+		})
+	}
+}
+
+func TestFormatCommands(t *testing.T) {
+	cmds := []string{`set node node1 resources_available.switch = "leaf1,spine"`, "set server node_group_enable = True"}
+
+	require.Equal(t, `set node node1 resources_available.switch = "leaf1,spine"
+set server node_group_enable = True
+`, string(formatCommands(cmds, FormatQmgr)))
+
+	require.Equal(t, ScriptHeader+`qmgr -c 'set node node1 resources_available.switch = "leaf1,spine"'
+qmgr -c 'set server node_group_enable = True'
+`, string(formatCommands(cmds, FormatScript)))
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/NVIDIA/topograph/internal/k8s"
 	"github.com/NVIDIA/topograph/pkg/engines"
 	"github.com/NVIDIA/topograph/pkg/engines/slurm"
+	"github.com/NVIDIA/topograph/pkg/metrics"
 	"github.com/NVIDIA/topograph/pkg/topology"
 	"github.com/NVIDIA/topograph/pkg/translate"
 )
@@ -71,8 +72,8 @@ func NamedLoader() (string, engines.Loader) {
 	return NAME, Loader
 }
 
-func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
-	p, err := getParameters(params)
+func Loader(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(cfg.Params)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
@@ -94,7 +95,7 @@ func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.
 	}, nil
 }
 
-func getParameters(params engines.Config) (*Params, error) {
+func getParameters(params map[string]any) (*Params, error) {
 	p := &Params{}
 	if err := config.Decode(params, p); err != nil {
 		return nil, err
@@ -248,15 +249,22 @@ func (eng *SlinkyEngine) GenerateOutput(ctx context.Context, root *topology.Vert
 	if httpErr := nt.Generate(buf); httpErr != nil {
 		return nil, httpErr
 	}
-	err = eng.UpdateTopologyConfigmap(ctx, p.ConfigMapName, p.Namespace, map[string]string{p.ConfigPath: buf.String()})
+	updated, err := eng.UpdateTopologyConfigmap(ctx, p.ConfigMapName, p.Namespace, map[string]string{p.ConfigPath: buf.String()})
 	if err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
 
+	if !updated {
+		metrics.AddUnchangedTopology(NAME)
+		return []byte(engines.UnchangedResult), nil
+	}
+
 	return []byte("OK\n"), nil
 }
 
-func (eng *SlinkyEngine) UpdateTopologyConfigmap(ctx context.Context, name, namespace string, data map[string]string) error {
+// UpdateTopologyConfigmap creates or updates the topology ConfigMap.
+// It returns false if the ConfigMap already holds the same topology config.
+func (eng *SlinkyEngine) UpdateTopologyConfigmap(ctx context.Context, name, namespace string, data map[string]string) (bool, error) {
 	klog.Infof("Updating topology config %s/%s", namespace, name)
 
 	annotations := eng.generateConfigMapAnnotations()
@@ -264,6 +272,10 @@ func (eng *SlinkyEngine) UpdateTopologyConfigmap(ctx context.Context, name, name
 	cmClient := eng.client.CoreV1().ConfigMaps(namespace)
 	cm, err := cmClient.Get(ctx, name, metav1.GetOptions{})
 	if err == nil {
+		if configMapDataUnchanged(cm.Data, data) {
+			klog.Infof("Topology config %s/%s is unchanged", namespace, name)
+			return false, nil
+		}
 		verb = "update"
 		if cm.Data == nil {
 			cm.Data = map[string]string{}
@@ -291,27 +303,38 @@ func (eng *SlinkyEngine) UpdateTopologyConfigmap(ctx context.Context, name, name
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to %s configmap %s/%s: %v",
+		return false, fmt.Errorf("failed to %s configmap %s/%s: %v",
 			verb, namespace, name, err)
 	}
 
 	klog.Infof("Successfully %sd configmap %s/%s", verb, namespace, name)
-	return nil
+	return true, nil
+}
+
+// configMapDataUnchanged returns true if every topology config in data matches the current ConfigMap data
+func configMapDataUnchanged(current, data map[string]string) bool {
+	for key, val := range data {
+		cur, ok := current[key]
+		if !ok || !slurm.EqualConfig(cur, val) {
+			return false
+		}
+	}
+	return true
 }
 
-func (eng *SlinkyEngine) getPartitionNodes(ctx context.Context, partition string, params []any) (string, error) {
+func (eng *SlinkyEngine) getPartitionNodes(ctx context.Context, partition string, params []any) ([]string, error) {
 	if len(params) != 1 {
-		return "", fmt.Errorf("getPartitionNodes expects a namespace as a parameter")
+		return nil, fmt.Errorf("getPartitionNodes expects a namespace as a parameter")
 	}
 	namespace, ok := params[0].(string)
 	if !ok {
-		return "", fmt.Errorf("getPartitionNodes expects a string parameter")
+		return nil, fmt.Errorf("getPartitionNodes expects a string parameter")
 	}
 
 	labels := map[string]string{"app.kubernetes.io/component": "login"}
 	pods, err := k8s.GetPodsByLabels(ctx, eng.client, namespace, labels)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	for _, pod := range pods.Items {
@@ -322,11 +345,11 @@ func (eng *SlinkyEngine) getPartitionNodes(ctx context.Context, partition string
 		cmd := []string{"scontrol", "show", "partition", partition}
 		buf, err := k8s.ExecInPod(ctx, eng.client, eng.config, pod.Name, pod.Namespace, cmd)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
-		return buf.String(), nil
+		return slurm.ParsePartitionNodes(partition, buf.String())
 	}
 
-	return "", fmt.Errorf("no running pods with labels %v", labels)
+	return nil, fmt.Errorf("no running pods with labels %v", labels)
 }
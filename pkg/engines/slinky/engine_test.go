@@ -308,3 +308,20 @@ func TestConfigMapAnnotationsAndMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMapDataUnchanged(t *testing.T) {
+	current := map[string]string{
+		"topology.conf": "# generated_at: 2026-01-01T00:00:00Z\nSwitchName=S1 Nodes=n[1-2]\n",
+		"other":         "value",
+	}
+
+	require.True(t, configMapDataUnchanged(current, map[string]string{
+		"topology.conf": "# generated_at: 2026-01-02T00:00:00Z\nSwitchName=S1 Nodes=n[1-2]\n",
+	}))
+	require.False(t, configMapDataUnchanged(current, map[string]string{
+		"topology.conf": "SwitchName=S1 Nodes=n[1-3]\n",
+	}))
+	require.False(t, configMapDataUnchanged(current, map[string]string{
+		"topology.yaml": "SwitchName=S1 Nodes=n[1-2]\n",
+	}))
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	BackendScontrol   = "scontrol"
+	BackendSlurmrestd = "slurmrestd"
+
+	fakePartition = "fake"
+)
+
+// Client provides access to the SLURM controller
+type Client interface {
+	// GetNodes returns all SLURM nodes
+	GetNodes(context.Context) ([]*Node, error)
+	// GetPartitions returns all SLURM partitions
+	GetPartitions(context.Context) ([]*Partition, error)
+	// GetPartitionNodes returns the list of nodes in the partition
+	GetPartitionNodes(context.Context, string) ([]string, error)
+	// Reconfigure instructs the SLURM controller to reload its configuration
+	Reconfigure(context.Context) error
+}
+
+var errConfReconfigure = errors.New("slurmConfPath and reconfigure parameters are mutually exclusive")
+
+// NewClient returns the SLURM client for the backend specified in the parameters
+func NewClient(params *Params, creds map[string]string) (Client, error) {
+	if len(params.SlurmConfPath) != 0 {
+		if len(params.Backend) != 0 {
+			return nil, fmt.Errorf("slurmConfPath and backend parameters are mutually exclusive")
+		}
+		if params.Reconfigure {
+			return nil, errConfReconfigure
+		}
+		return newConfClient(params.SlurmConfPath)
+	}
+
+	switch params.Backend {
+	case "", BackendScontrol:
+		return &scontrolClient{}, nil
+	case BackendSlurmrestd:
+		return newRestClient(params, creds)
+	default:
+		return nil, fmt.Errorf("unsupported backend %q", params.Backend)
+	}
+}
+
+func newTopologyNodeFinder(client Client) *TopologyNodeFinder {
+	return &TopologyNodeFinder{
+		GetPartitionNodes: func(ctx context.Context, partition string, _ []any) ([]string, error) {
+			return client.GetPartitionNodes(ctx, partition)
+		},
+		GetFakeNodes: func(ctx context.Context) (string, error) {
+			nodes, err := client.GetPartitionNodes(ctx, fakePartition)
+			if err != nil {
+				return "", err
+			}
+			return strings.Join(nodes, ","), nil
+		},
+	}
+}
+
+// scontrolClient accesses the SLURM controller with the "scontrol" command
+type scontrolClient struct{}
+
+func (c *scontrolClient) GetNodes(ctx context.Context) ([]*Node, error) {
+	return GetNodes(ctx)
+}
+
+func (c *scontrolClient) GetPartitions(ctx context.Context) ([]*Partition, error) {
+	return GetPartitions(ctx)
+}
+
+func (c *scontrolClient) GetPartitionNodes(ctx context.Context, partition string) ([]string, error) {
+	return getPartitionNodes(ctx, partition)
+}
+
+func (c *scontrolClient) Reconfigure(ctx context.Context) error {
+	return reconfigure(ctx)
+}
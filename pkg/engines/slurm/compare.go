@@ -0,0 +1,55 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// EqualConfig compares two topology configs, ignoring the "generated_at" and "provider" headers,
+// empty lines and the order of comment lines
+func EqualConfig(a, b string) bool {
+	linesA, commentsA := splitConfig(a)
+	linesB, commentsB := splitConfig(b)
+
+	return slices.Equal(linesA, linesB) && slices.Equal(commentsA, commentsB)
+}
+
+// splitConfig returns config lines in the original order and sorted comment lines
+func splitConfig(data string) ([]string, []string) {
+	lines := []string{}
+	comments := []string{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case len(line) == 0:
+			// skip
+		case strings.HasPrefix(line, "#"):
+			text := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			if !isHeader(text) {
+				comments = append(comments, text)
+			}
+		default:
+			lines = append(lines, line)
+		}
+	}
+	slices.Sort(comments)
+
+	return lines, comments
+}
+
+// isHeader returns true for the header comments of the root metadata
+func isHeader(text string) bool {
+	for _, key := range topology.HeaderKeys {
+		if strings.HasPrefix(text, key+":") {
+			return true
+		}
+	}
+	return false
+}
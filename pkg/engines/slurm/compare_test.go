@@ -0,0 +1,130 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestEqualConfig(t *testing.T) {
+	config := `# generated_at: 2026-01-01T00:00:00Z
+# switch.2.1=spine-1
+SwitchName=switch.2.1 Switches=switch.1.[1-2]
+# switch.1.1=leaf-1
+SwitchName=switch.1.1 Nodes=node[1-2]
+# switch.1.2=leaf-2
+SwitchName=switch.1.2 Nodes=node[3-4]
+`
+	testCases := []struct {
+		name  string
+		other string
+		equal bool
+	}{
+		{
+			name:  "Case 1: identical",
+			other: config,
+			equal: true,
+		},
+		{
+			name: "Case 2: different headers and comment order",
+			other: `# generated_at: 2026-02-02T00:00:00Z
+# provider: oci-imds
+# switch.1.2=leaf-2
+# switch.2.1=spine-1
+SwitchName=switch.2.1 Switches=switch.1.[1-2]
+
+SwitchName=switch.1.1 Nodes=node[1-2]
+# switch.1.1=leaf-1
+SwitchName=switch.1.2 Nodes=node[3-4]
+`,
+			equal: true,
+		},
+		{
+			name: "Case 3: no timestamp",
+			other: `# switch.2.1=spine-1
+SwitchName=switch.2.1 Switches=switch.1.[1-2]
+# switch.1.1=leaf-1
+SwitchName=switch.1.1 Nodes=node[1-2]
+# switch.1.2=leaf-2
+SwitchName=switch.1.2 Nodes=node[3-4]
+`,
+			equal: true,
+		},
+		{
+			name: "Case 4: different nodes",
+			other: `# switch.2.1=spine-1
+SwitchName=switch.2.1 Switches=switch.1.[1-2]
+# switch.1.1=leaf-1
+SwitchName=switch.1.1 Nodes=node[1-2]
+# switch.1.2=leaf-2
+SwitchName=switch.1.2 Nodes=node[3-5]
+`,
+		},
+		{
+			name: "Case 5: different switch names",
+			other: `# switch.2.1=spine-1
+SwitchName=switch.2.1 Switches=switch.1.[1-2]
+# switch.1.1=leaf-2
+SwitchName=switch.1.1 Nodes=node[1-2]
+# switch.1.2=leaf-1
+SwitchName=switch.1.2 Nodes=node[3-4]
+`,
+		},
+		{
+			name: "Case 6: different line order",
+			other: `# switch.2.1=spine-1
+SwitchName=switch.2.1 Switches=switch.1.[1-2]
+# switch.1.2=leaf-2
+SwitchName=switch.1.2 Nodes=node[3-4]
+# switch.1.1=leaf-1
+SwitchName=switch.1.1 Nodes=node[1-2]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.equal, EqualConfig(config, tc.other))
+		})
+	}
+}
+
+func TestGenerateOutputUnchanged(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "topology.conf")
+
+	root, _ := translate.GetTreeTestSet(false)
+	root.Metadata = map[string]string{topology.KeyGeneratedAt: "2026-01-01T00:00:00Z"}
+	params := &Params{TopoConfigPath: path}
+
+	out, err := GenerateOutputParams(ctx, root, params)
+	require.Nil(t, err)
+	require.Equal(t, "OK\n", string(out))
+	data, _ := os.ReadFile(path)
+
+	root.Metadata[topology.KeyGeneratedAt] = "2026-01-02T00:00:00Z"
+	out, err = GenerateOutputParams(ctx, root, params)
+	require.Nil(t, err)
+	require.Equal(t, engines.UnchangedResult, string(out))
+
+	// the file is not rewritten
+	current, _ := os.ReadFile(path)
+	require.Equal(t, data, current)
+
+	delete(root.Vertices[topology.TopologyTree].Vertices["S1"].Vertices, "S3")
+	out, err = GenerateOutputParams(ctx, root, params)
+	require.Nil(t, err)
+	require.Equal(t, "OK\n", string(out))
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+// configFile is a generated config file to deploy
+type configFile struct {
+	path     string
+	data     []byte
+	validate bool   // validate the file as topology config
+	current  []byte // current content, or nil if the file does not exist
+}
+
+// deployConfig replaces the config files at their paths.
+// The new topology config is optionally validated, the current configs are backed up,
+// the changed configs are written atomically, and the current configs are restored
+// if SLURM reconfiguration fails.
+func deployConfig(ctx context.Context, params *Params, client Client, cfgs ...*configFile) ([]byte, *httperr.Error) {
+	changed := []*configFile{}
+	for _, cfg := range cfgs {
+		current, err := readConfig(cfg.path)
+		if err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+		cfg.current = current
+
+		if current != nil && EqualConfig(string(current), string(cfg.data)) {
+			klog.Infof("Config in %q is unchanged", cfg.path)
+			continue
+		}
+		changed = append(changed, cfg)
+	}
+
+	if len(changed) == 0 {
+		metrics.AddUnchangedTopology(NAME)
+		return []byte(engines.UnchangedResult), nil
+	}
+
+	if params.Validate {
+		for _, cfg := range changed {
+			if !cfg.validate {
+				continue
+			}
+			if err := validateConfig(ctx, client, cfg.data); err != nil {
+				return nil, httperr.NewError(http.StatusUnprocessableEntity, err.Error())
+			}
+		}
+	}
+
+	for _, cfg := range changed {
+		if cfg.current != nil && params.BackupCount > 0 {
+			backup, err := files.Backup(cfg.path, cfg.current, params.BackupCount)
+			if err != nil {
+				return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+			}
+			klog.Infof("Saved config backup in %q", backup)
+		}
+	}
+
+	for i, cfg := range changed {
+		klog.Infof("Writing config in %q", cfg.path)
+		if err := files.CreateAtomic(cfg.path, cfg.data); err != nil {
+			if i != 0 {
+				return nil, httperr.NewError(http.StatusInternalServerError, rollback(changed[:i], err))
+			}
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if params.Reconfigure {
+		if err := client.Reconfigure(ctx); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, rollback(changed, err))
+		}
+	}
+
+	return []byte("OK\n"), nil
+}
+
+// readConfig returns the content of the current topology config, or nil if it does not exist
+func readConfig(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %q: %v", path, err)
+	}
+	return data, nil
+}
+
+// rollback restores the previous configs and returns the error message describing the outcome
+func rollback(cfgs []*configFile, reason error) string {
+	for _, cfg := range cfgs {
+		klog.Warningf("Restoring previous config in %q", cfg.path)
+
+		var err error
+		if cfg.current == nil {
+			err = os.Remove(cfg.path)
+		} else {
+			err = files.CreateAtomic(cfg.path, cfg.current)
+		}
+		if err != nil {
+			klog.Errorf("Failed to restore previous config in %q: %v", cfg.path, err)
+			return fmt.Sprintf("%v; failed to roll back topology config: %v", reason, err)
+		}
+	}
+
+	metrics.AddTopologyRollback(NAME)
+	return fmt.Sprintf("%v; rolled back to previous topology config", reason)
+}
+
+// validateConfig checks that the topology config is well-formed,
+// every node is listed once, and all nodes are known to SLURM
+func validateConfig(ctx context.Context, client Client, data []byte) error {
+	root, err := translate.Parse(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid topology config: %v", err)
+	}
+
+	nodeList, err := client.GetNodes(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool)
+	for _, node := range nodeList {
+		known[node.Name] = true
+	}
+
+	missing := []string{}
+	for node := range configNodes(root, topology.TopologyTree, topology.TopologyBlock) {
+		if !known[node] {
+			missing = append(missing, node)
+		}
+	}
+
+	if len(missing) != 0 {
+		return fmt.Errorf("invalid topology config: unknown nodes %s", strings.Join(cluset.Compact(missing), ","))
+	}
+
+	return nil
+}
+
+// configNodes returns the set of node names in the given tree and block topologies
+func configNodes(root *topology.Vertex, topologies ...string) map[string]bool {
+	nodes := make(map[string]bool)
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok && slices.Contains(topologies, topology.TopologyTree) {
+		queue := []*topology.Vertex{tree}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, w := range v.Vertices {
+				if len(w.Vertices) == 0 {
+					nodes[w.Name] = true
+				} else {
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+
+	if blocks, ok := root.Vertices[topology.TopologyBlock]; ok && slices.Contains(topologies, topology.TopologyBlock) {
+		for _, block := range blocks.Vertices {
+			for _, w := range block.Vertices {
+				nodes[w.Name] = true
+			}
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+// fakeScontrol installs a "scontrol" script into PATH, which lists the given nodes
+// and fails "scontrol reconfigure" if reconfigureFails is set
+func fakeScontrol(t *testing.T, nodes string, reconfigureFails bool) {
+	dir := t.TempDir()
+	status := "0"
+	if reconfigureFails {
+		status = "1"
+	}
+	script := `#!/bin/sh
+case "$1" in
+show)
+  for node in ` + nodes + `; do echo "NodeName=$node Arch=x86_64 State=IDLE"; done ;;
+reconfigure)
+  echo "reconfigure error" >&2
+  exit ` + status + ` ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scontrol"), []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestDeployConfig(t *testing.T) {
+	ctx := context.TODO()
+	config := "SwitchName=S1 Nodes=node[1-2]\n"
+	newConfig := "SwitchName=S1 Nodes=node[1-3]\n"
+
+	testCases := []struct {
+		name             string
+		current          string
+		nodes            string
+		reconfigureFails bool
+		params           *Params
+		out              string
+		err              string
+		config           string
+		backups          int
+	}{
+		{
+			name:    "Case 1: new config with backup",
+			current: config,
+			params:  &Params{BackupCount: 2},
+			out:     "OK\n",
+			config:  newConfig,
+			backups: 1,
+		},
+		{
+			name:   "Case 2: no current config",
+			params: &Params{BackupCount: 2},
+			out:    "OK\n",
+			config: newConfig,
+		},
+		{
+			name:    "Case 3: valid config",
+			current: config,
+			nodes:   "node1 node2 node3 node4",
+			params:  &Params{Validate: true},
+			out:     "OK\n",
+			config:  newConfig,
+		},
+		{
+			name:    "Case 4: unknown nodes",
+			current: config,
+			nodes:   "node1 node2",
+			params:  &Params{Validate: true},
+			err:     "invalid topology config: unknown nodes node3",
+			config:  config,
+		},
+		{
+			name:    "Case 5: reconfigure succeeds",
+			current: config,
+			params:  &Params{Reconfigure: true},
+			out:     "OK\n",
+			config:  newConfig,
+		},
+		{
+			name:             "Case 6: reconfigure fails",
+			current:          config,
+			reconfigureFails: true,
+			params:           &Params{Reconfigure: true, BackupCount: 1},
+			err:              "scontrol failed: reconfigure error\n : exit status 1; rolled back to previous topology config",
+			config:           config,
+			backups:          1,
+		},
+		{
+			name:             "Case 7: reconfigure fails without current config",
+			reconfigureFails: true,
+			params:           &Params{Reconfigure: true},
+			err:              "scontrol failed: reconfigure error\n : exit status 1; rolled back to previous topology config",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeScontrol(t, tc.nodes, tc.reconfigureFails)
+			path := filepath.Join(t.TempDir(), "topology.conf")
+			if len(tc.current) != 0 {
+				require.NoError(t, os.WriteFile(path, []byte(tc.current), 0644))
+			}
+
+			out, err := deployConfig(ctx, tc.params, &scontrolClient{}, &configFile{path: path, data: []byte(newConfig), validate: true})
+			if len(tc.err) != 0 {
+				require.NotNil(t, err)
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.out, string(out))
+			}
+
+			data, rerr := os.ReadFile(path)
+			if len(tc.config) == 0 {
+				require.True(t, os.IsNotExist(rerr))
+			} else {
+				require.NoError(t, rerr)
+				require.Equal(t, tc.config, string(data))
+			}
+
+			backups, berr := files.ListBackups(path)
+			require.NoError(t, berr)
+			require.Len(t, backups, tc.backups)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	fakeScontrol(t, "Node201 Node202 Node205 Node304 Node305 Node306", false)
+
+	require.NoError(t, validateConfig(context.TODO(), &scontrolClient{}, []byte(`
+###############################################################
+# Slurm's network topology configuration file for use with the
+# topology/tree plugin
+###############################################################
+SwitchName=S1 Switches=S[2-3]
+SwitchName=S2 Nodes=Node[201-202,205]
+SwitchName=S3 Nodes=Node[304-306]
+`)))
+
+	err := validateConfig(context.TODO(), &scontrolClient{}, []byte("SwitchName=S2 Nodes=Node[201-202]\nSwitchName=S3 Nodes=Node[202,304]\n"))
+	require.EqualError(t, err, `invalid topology config: node "Node202" is connected to switches "S2" and "S3"`)
+
+	err = validateConfig(context.TODO(), &scontrolClient{}, []byte("BlockName=B1 Nodes=Node[201-202,401-402]\nBlockSizes=4\n"))
+	require.EqualError(t, err, "invalid topology config: unknown nodes Node[401-402]")
+
+	root, _ := translate.GetBlockWithMultiIBTestSet()
+	require.Len(t, configNodes(root, topology.TopologyTree, topology.TopologyBlock), 12)
+}
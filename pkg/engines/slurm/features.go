@@ -0,0 +1,99 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const NodeFeaturesHeader = `###############################################################
+# Slurm node features derived from the network topology
+###############################################################
+`
+
+// nvlFeaturePrefix prefixes the NVLink domain features
+const nvlFeaturePrefix = "nvl-"
+
+var invalidFeatureRe = regexp.MustCompile(`[^A-Za-z0-9_.\-]`)
+
+// generateNodeFeatures returns "NodeName=<hostlist> Features=<list>" lines assigning
+// every node its existing features, block ID, NVLink domain and leaf switch name.
+// Nodes with the same features share a line.
+func generateNodeFeatures(root *topology.Vertex, existing map[string]string) []byte {
+	features := make(map[string][]string)
+	add := func(node string, feature ...string) {
+		for _, f := range feature {
+			if !slices.Contains(features[node], f) {
+				features[node] = append(features[node], f)
+			}
+		}
+	}
+
+	for _, hostlist := range slices.Sorted(maps.Keys(existing)) {
+		for _, node := range cluset.ExpandList(hostlist) {
+			add(node, splitList(existing[hostlist])...)
+		}
+	}
+
+	if blocks, ok := root.Vertices[topology.TopologyBlock]; ok {
+		for _, block := range blocks.Vertices {
+			for _, w := range block.Vertices {
+				add(w.Name, featureName(block.ID))
+				if len(block.Name) != 0 {
+					add(w.Name, nvlFeaturePrefix+featureName(block.Name))
+				}
+			}
+		}
+	}
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok {
+		addLeafSwitchFeatures(tree, add)
+	}
+
+	// group nodes by features
+	groups := make(map[string][]string)
+	for node, list := range features {
+		key := strings.Join(list, ",")
+		groups[key] = append(groups[key], node)
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(NodeFeaturesHeader)
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		fmt.Fprintf(buf, "NodeName=%s Features=%s\n", strings.Join(cluset.Compact(groups[key]), ","), key)
+	}
+
+	return buf.Bytes()
+}
+
+// addLeafSwitchFeatures assigns the leaf switch name to its nodes
+func addLeafSwitchFeatures(v *topology.Vertex, add func(string, ...string)) {
+	for _, w := range v.Vertices {
+		if len(w.Vertices) == 0 {
+			if len(v.ID) != 0 && v.ID != topology.NoTopology {
+				name := v.Name
+				if len(name) == 0 {
+					name = v.ID
+				}
+				add(w.Name, featureName(name))
+			}
+			continue
+		}
+		addLeafSwitchFeatures(w, add)
+	}
+}
+
+// featureName replaces characters not allowed in SLURM feature names
+func featureName(name string) string {
+	return invalidFeatureRe.ReplaceAllString(name, "_")
+}
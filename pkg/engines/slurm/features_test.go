@@ -0,0 +1,82 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestGenerateNodeFeatures(t *testing.T) {
+	root := getAutoPartitionsTestSet()
+	root.Vertices[topology.TopologyTree].Vertices["sw1"].Name = "leaf 1"
+
+	testCases := []struct {
+		name     string
+		existing map[string]string
+		features string
+	}{
+		{
+			name: "Case 1: topology features",
+			features: NodeFeaturesHeader + `NodeName=node[1-2] Features=block001,nvl-nvl1,leaf_1
+NodeName=node3 Features=leaf_1
+`,
+		},
+		{
+			name:     "Case 2: existing features",
+			existing: map[string]string{"node[1-4]": "gpu,h100", "node3": "leaf_1"},
+			features: NodeFeaturesHeader + `NodeName=node4 Features=gpu,h100
+NodeName=node[1-2] Features=gpu,h100,block001,nvl-nvl1,leaf_1
+NodeName=node3 Features=leaf_1,gpu,h100
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.features, string(generateNodeFeatures(root, tc.existing)))
+		})
+	}
+}
+
+func TestNodeFeaturesFile(t *testing.T) {
+	ctx := context.TODO()
+	client := &testClient{
+		partitions: []*Partition{{Name: "batch", Nodes: []string{"node[1-3]"}, Default: true}},
+	}
+	root := getAutoPartitionsTestSet()
+
+	_, httpErr := generateOutput(ctx, root, &Params{NodeFeaturesFile: "features.conf"}, client)
+	require.EqualError(t, httpErr, "nodeFeaturesFile parameter requires topologyConfigPath")
+
+	dir := t.TempDir()
+	params := &Params{
+		TopoConfigPath:   filepath.Join(dir, "topology.conf"),
+		NodeFeaturesFile: "features.conf",
+		ExistingFeatures: map[string]string{"node4": "cpu"},
+	}
+	out, httpErr := generateOutput(ctx, root, params, client)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(out))
+
+	features, err := os.ReadFile(filepath.Join(dir, "features.conf"))
+	require.NoError(t, err)
+	require.Equal(t, NodeFeaturesHeader+`NodeName=node[1-2] Features=block001,nvl-nvl1,sw1
+NodeName=node4 Features=cpu
+NodeName=node3 Features=sw1
+`, string(features))
+	require.FileExists(t, params.TopoConfigPath)
+
+	out, httpErr = generateOutput(ctx, root, params, client)
+	require.Nil(t, httpErr)
+	require.Equal(t, "UNCHANGED\n", string(out))
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+)
+
+const (
+	// PowerSavedInclude treats power-saved nodes as any other node
+	PowerSavedInclude = "include"
+	// PowerSavedKeep keeps the placement of power-saved nodes from the deployed topology config
+	PowerSavedKeep = "keep"
+	// PowerSavedExclude leaves power-saved nodes out of the topology config
+	PowerSavedExclude = "exclude"
+)
+
+// powerSavedStates are the state flags of cloud nodes without a running instance
+var powerSavedStates = []string{"POWERED_DOWN", "POWER_DOWN", "POWERING_UP"}
+
+var nodeAttrRe = regexp.MustCompile(`(?:^|\s)(NodeName|State|AvailableFeatures|Features|Gres)=(\S*)`)
+
+// Node describes a SLURM node
+type Node struct {
+	Name string
+	// State contains the base state followed by the state flags, e.g. IDLE, CLOUD, POWERED_DOWN
+	State    []string
+	Features []string
+	Gres     []string
+}
+
+// NodeFilter selects the SLURM nodes for topology discovery.
+// Include lists keep nodes matching any of the entries; exclude lists drop them.
+type NodeFilter struct {
+	IncludeStates   []string `mapstructure:"includeStates"`
+	ExcludeStates   []string `mapstructure:"excludeStates"`
+	IncludeFeatures []string `mapstructure:"includeFeatures"`
+	ExcludeFeatures []string `mapstructure:"excludeFeatures"`
+	IncludeGres     []string `mapstructure:"includeGres"`
+	ExcludeGres     []string `mapstructure:"excludeGres"`
+	// PowerSaved (optional) specifies the policy for power-saved cloud nodes:
+	// "include" (default), "keep" or "exclude"
+	PowerSaved string `mapstructure:"powerSaved"`
+}
+
+// GetNodes returns the SLURM nodes reported by "scontrol show nodes"
+func GetNodes(ctx context.Context) ([]*Node, error) {
+	stdout, err := exec.Exec(ctx, "scontrol", []string{"show", "nodes", "-o"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("stdout: %s", stdout.String())
+
+	return parseNodes(stdout.String())
+}
+
+// parseNodes parses "scontrol show nodes -o" output
+func parseNodes(data string) ([]*Node, error) {
+	nodes := []*Node{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "NodeName=") {
+			continue
+		}
+
+		attrs := make(map[string]string)
+		for _, match := range nodeAttrRe.FindAllStringSubmatch(line, -1) {
+			attrs[match[1]] = match[2]
+		}
+
+		features, ok := attrs["AvailableFeatures"]
+		if !ok {
+			features = attrs["Features"]
+		}
+
+		nodes = append(nodes, &Node{
+			Name:     attrs["NodeName"],
+			State:    parseNodeState(attrs["State"]),
+			Features: splitList(features),
+			Gres:     splitGres(attrs["Gres"]),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed scan output: %v", err)
+	}
+
+	return nodes, nil
+}
+
+// parseNodeState splits node state such as "IDLE*+CLOUD+POWERED_DOWN"
+// and strips the single-character state suffixes
+func parseNodeState(state string) []string {
+	flags := []string{}
+	for _, flag := range strings.Split(state, "+") {
+		if flag = strings.TrimRight(flag, "*~#%!$@^-"); len(flag) != 0 {
+			flags = append(flags, strings.ToUpper(flag))
+		}
+	}
+	return flags
+}
+
+func splitList(str string) []string {
+	if len(str) == 0 || str == "(null)" {
+		return nil
+	}
+	return strings.Split(str, ",")
+}
+
+// splitGres splits GRES list such as "gpu:h100:8(S:0,1),nic:2" into entries
+// without socket bindings
+func splitGres(str string) []string {
+	if len(str) == 0 || str == "(null)" {
+		return nil
+	}
+
+	gres := []string{}
+	depth, start := 0, 0
+	for i, c := range str + "," {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				if entry, _, _ := strings.Cut(str[start:i], "("); len(entry) != 0 {
+					gres = append(gres, entry)
+				}
+				start = i + 1
+			}
+		}
+	}
+	return gres
+}
+
+// IsPowerSaved returns true if the node is a powered down cloud node
+func (n *Node) IsPowerSaved() bool {
+	for _, state := range powerSavedStates {
+		if slices.Contains(n.State, state) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) matchState(states []string) bool {
+	for _, state := range states {
+		if slices.Contains(n.State, strings.ToUpper(state)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) matchFeature(features []string) bool {
+	for _, feature := range features {
+		if slices.Contains(n.Features, feature) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGres returns true if the node has any of the GRES.
+// A GRES filter matches by name ("gpu") or by name and type ("gpu:h100").
+func (n *Node) matchGres(gres []string) bool {
+	for _, g := range gres {
+		for _, entry := range n.Gres {
+			if entry == g || strings.HasPrefix(entry, g+":") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate checks the filter parameters
+func (f *NodeFilter) Validate() error {
+	switch f.PowerSaved {
+	case "", PowerSavedInclude, PowerSavedKeep, PowerSavedExclude:
+		return nil
+	default:
+		return fmt.Errorf("unsupported powerSaved policy %q", f.PowerSaved)
+	}
+}
+
+// Apply returns the names of the selected nodes to be discovered,
+// and the names of the selected power-saved nodes, if they are not discovered by policy
+func (f *NodeFilter) Apply(nodes []*Node) ([]string, []string) {
+	selected := []string{}
+	powerSaved := []string{}
+	for _, node := range nodes {
+		if !f.match(node) {
+			klog.V(4).Infof("Skipping node %s with state %v features %v gres %v", node.Name, node.State, node.Features, node.Gres)
+			continue
+		}
+		if node.IsPowerSaved() && f.PowerSaved != "" && f.PowerSaved != PowerSavedInclude {
+			powerSaved = append(powerSaved, node.Name)
+			continue
+		}
+		selected = append(selected, node.Name)
+	}
+
+	return selected, powerSaved
+}
+
+func (f *NodeFilter) match(node *Node) bool {
+	if len(f.IncludeStates) != 0 && !node.matchState(f.IncludeStates) {
+		return false
+	}
+	if len(f.ExcludeStates) != 0 && node.matchState(f.ExcludeStates) {
+		return false
+	}
+	if len(f.IncludeFeatures) != 0 && !node.matchFeature(f.IncludeFeatures) {
+		return false
+	}
+	if len(f.ExcludeFeatures) != 0 && node.matchFeature(f.ExcludeFeatures) {
+		return false
+	}
+	if len(f.IncludeGres) != 0 && !node.matchGres(f.IncludeGres) {
+		return false
+	}
+	if len(f.ExcludeGres) != 0 && node.matchGres(f.ExcludeGres) {
+		return false
+	}
+	return true
+}
+
+func nodeNames(nodes []*Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
@@ -0,0 +1,304 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const testNodes = `NodeName=node1 Arch=x86_64 CoresPerSocket=56 CPUAlloc=0 CPUTot=224 AvailableFeatures=gpu,h100 ActiveFeatures=gpu,h100 Gres=gpu:h100:8(S:0-1) NodeAddr=node1 State=IDLE ThreadsPerCore=2 Partitions=batch
+NodeName=node2 Arch=x86_64 CoresPerSocket=56 CPUAlloc=0 CPUTot=224 AvailableFeatures=gpu,h100 ActiveFeatures=gpu,h100 Gres=gpu:h100:8(S:0,1),nic:2 NodeAddr=node2 State=IDLE+DRAIN ThreadsPerCore=2 Reason=Maintenance [root@2026-01-02T03:04:05] Partitions=batch
+NodeName=node3 Arch=x86_64 CoresPerSocket=56 CPUAlloc=0 CPUTot=224 AvailableFeatures=gpu,h100 ActiveFeatures=(null) Gres=gpu:h100:8 NodeAddr=node3 State=IDLE+CLOUD+POWERED_DOWN ThreadsPerCore=2 Partitions=batch
+NodeName=cpu1 Arch=x86_64 CoresPerSocket=32 CPUAlloc=0 CPUTot=64 AvailableFeatures=(null) ActiveFeatures=(null) Gres=(null) NodeAddr=cpu1 State=DOWN* ThreadsPerCore=2 Partitions=cpu
+NodeName=cpu2 Arch=x86_64 CoresPerSocket=32 CPUAlloc=0 CPUTot=64 Features=cpu Gres=(null) NodeAddr=cpu2 State=FUTURE ThreadsPerCore=2 Partitions=cpu
+`
+
+func TestParseNodes(t *testing.T) {
+	nodes, err := parseNodes(testNodes)
+	require.NoError(t, err)
+
+	expected := []*Node{
+		{
+			Name:     "node1",
+			State:    []string{"IDLE"},
+			Features: []string{"gpu", "h100"},
+			Gres:     []string{"gpu:h100:8"},
+		},
+		{
+			Name:     "node2",
+			State:    []string{"IDLE", "DRAIN"},
+			Features: []string{"gpu", "h100"},
+			Gres:     []string{"gpu:h100:8", "nic:2"},
+		},
+		{
+			Name:     "node3",
+			State:    []string{"IDLE", "CLOUD", "POWERED_DOWN"},
+			Features: []string{"gpu", "h100"},
+			Gres:     []string{"gpu:h100:8"},
+		},
+		{
+			Name:  "cpu1",
+			State: []string{"DOWN"},
+		},
+		{
+			Name:     "cpu2",
+			State:    []string{"FUTURE"},
+			Features: []string{"cpu"},
+		},
+	}
+	require.Equal(t, expected, nodes)
+}
+
+func TestNodeFilter(t *testing.T) {
+	nodes, err := parseNodes(testNodes)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		filter     NodeFilter
+		selected   []string
+		powerSaved []string
+		err        string
+	}{
+		{
+			name:     "Case 1: no filter",
+			selected: []string{"node1", "node2", "node3", "cpu1", "cpu2"},
+		},
+		{
+			name:     "Case 2: exclude states",
+			filter:   NodeFilter{ExcludeStates: []string{"down", "drain", "future"}},
+			selected: []string{"node1", "node3"},
+		},
+		{
+			name:     "Case 3: include states",
+			filter:   NodeFilter{IncludeStates: []string{"IDLE"}, ExcludeStates: []string{"DRAIN"}},
+			selected: []string{"node1", "node3"},
+		},
+		{
+			name:     "Case 4: include features",
+			filter:   NodeFilter{IncludeFeatures: []string{"gpu"}},
+			selected: []string{"node1", "node2", "node3"},
+		},
+		{
+			name:     "Case 5: exclude features",
+			filter:   NodeFilter{ExcludeFeatures: []string{"gpu"}},
+			selected: []string{"cpu1", "cpu2"},
+		},
+		{
+			name:     "Case 6: include GRES by name",
+			filter:   NodeFilter{IncludeGres: []string{"gpu"}},
+			selected: []string{"node1", "node2", "node3"},
+		},
+		{
+			name:     "Case 7: GRES by name and type",
+			filter:   NodeFilter{IncludeGres: []string{"gpu:h100"}, ExcludeGres: []string{"nic"}},
+			selected: []string{"node1", "node3"},
+		},
+		{
+			name:       "Case 8: keep power-saved nodes",
+			filter:     NodeFilter{IncludeGres: []string{"gpu"}, PowerSaved: PowerSavedKeep},
+			selected:   []string{"node1", "node2"},
+			powerSaved: []string{"node3"},
+		},
+		{
+			name:       "Case 9: exclude power-saved nodes",
+			filter:     NodeFilter{PowerSaved: PowerSavedExclude},
+			selected:   []string{"node1", "node2", "cpu1", "cpu2"},
+			powerSaved: []string{"node3"},
+		},
+		{
+			name:     "Case 10: include power-saved nodes",
+			filter:   NodeFilter{IncludeFeatures: []string{"h100"}, PowerSaved: PowerSavedInclude},
+			selected: []string{"node1", "node2", "node3"},
+		},
+		{
+			name:   "Case 11: invalid policy",
+			filter: NodeFilter{PowerSaved: "bad"},
+			err:    `unsupported powerSaved policy "bad"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.filter.Validate()
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			selected, powerSaved := tc.filter.Apply(nodes)
+			require.Equal(t, tc.selected, selected)
+			if len(tc.powerSaved) == 0 {
+				require.Empty(t, powerSaved)
+			} else {
+				require.Equal(t, tc.powerSaved, powerSaved)
+			}
+		})
+	}
+}
+
+type testInstanceMapper struct{}
+
+func (m *testInstanceMapper) Instances2NodeMap(_ context.Context, nodes []string) (map[string]string, error) {
+	i2n := make(map[string]string)
+	for _, node := range nodes {
+		i2n["i-"+node] = node
+	}
+	return i2n, nil
+}
+
+func (m *testInstanceMapper) GetInstancesRegions(_ context.Context, nodes []string) (map[string]string, error) {
+	regions := make(map[string]string)
+	for _, node := range nodes {
+		regions[node] = "region"
+	}
+	return regions, nil
+}
+
+func TestKeepPowerSavedNodes(t *testing.T) {
+	ctx := context.TODO()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scontrol"), []byte("#!/bin/sh\ncat <<'EOF'\n"+testNodes+"EOF\n"), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	path := filepath.Join(dir, "topology.conf")
+	deployed := `# spine=ibspine
+SwitchName=spine Switches=leaf[1-2]
+# leaf1=ibleaf1
+SwitchName=leaf1 Nodes=node[1-2]
+# leaf2=ibleaf2
+SwitchName=leaf2 Nodes=node3
+`
+	require.NoError(t, os.WriteFile(path, []byte(deployed), 0644))
+
+	params := map[string]any{
+		"topologyConfigPath": path,
+		"nodeFilter": map[string]any{
+			"includeGres": []string{"gpu"},
+			"powerSaved":  PowerSavedKeep,
+		},
+	}
+	eng, httpErr := Loader(ctx, engines.Config{Params: params})
+	require.Nil(t, httpErr)
+
+	cis, httpErr := eng.GetComputeInstances(ctx, &testInstanceMapper{})
+	require.Nil(t, httpErr)
+	require.Equal(t, []topology.ComputeInstances{
+		{
+			Region:    "region",
+			Instances: map[string]string{"i-node1": "node1", "i-node2": "node2"},
+		},
+	}, cis)
+
+	// the provider reports only the discovered nodes; leaf2 is gone
+	root := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"ibspine": {
+						ID:   "ibspine",
+						Name: "spine",
+						Vertices: map[string]*topology.Vertex{
+							"ibleaf1": {
+								ID:   "ibleaf1",
+								Name: "leaf1",
+								Vertices: map[string]*topology.Vertex{
+									"i-node1": {ID: "i-node1", Name: "node1"},
+									"i-node2": {ID: "i-node2", Name: "node2"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	out, httpErr := eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(out))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), deployed)
+}
+
+func TestKeepPlacement(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topology.conf")
+	deployed := `# S1=sw1
+SwitchName=S1 Switches=S[2-3]
+# S2=sw2
+SwitchName=S2 Nodes=n[1-2]
+# S3=sw3
+SwitchName=S3 Nodes=n[3-4]
+# block001=nvl1
+BlockName=block001 Nodes=n[1-2]
+# block002=nvl2
+BlockName=block002 Nodes=n[3-4]
+BlockSizes=2
+`
+	require.NoError(t, os.WriteFile(path, []byte(deployed), 0644))
+
+	root := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"sw1": {
+						ID:   "sw1",
+						Name: "S1",
+						Vertices: map[string]*topology.Vertex{
+							"sw2": {
+								ID:       "sw2",
+								Name:     "S2",
+								Vertices: map[string]*topology.Vertex{"i1": {ID: "i1", Name: "n1"}},
+							},
+						},
+					},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"nvl1": {
+						ID:       "block001",
+						Name:     "nvl1",
+						Vertices: map[string]*topology.Vertex{"n1": {ID: "i1", Name: "n1"}},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, keepPlacement(root, path, []string{"n2", "n3", "n5"}))
+
+	sw1 := root.Vertices[topology.TopologyTree].Vertices["sw1"]
+	require.Equal(t, &topology.Vertex{ID: "n2", Name: "n2"}, sw1.Vertices["sw2"].Vertices["n2"])
+	require.Equal(t, &topology.Vertex{
+		ID:       "sw3",
+		Name:     "S3",
+		Vertices: map[string]*topology.Vertex{"n3": {ID: "n3", Name: "n3"}},
+	}, sw1.Vertices["sw3"])
+
+	blocks := root.Vertices[topology.TopologyBlock].Vertices
+	require.Len(t, blocks, 2)
+	require.Contains(t, blocks["nvl1"].Vertices, "n2")
+	require.Equal(t, &topology.Vertex{
+		ID:       "block002",
+		Name:     "nvl2",
+		Vertices: map[string]*topology.Vertex{"n3": {ID: "n3", Name: "n3"}},
+	}, blocks["nvl2"])
+
+	// missing config is not an error
+	require.NoError(t, keepPlacement(root, filepath.Join(t.TempDir(), "missing.conf"), []string{"n4"}))
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+var partitionAttrRe = regexp.MustCompile(`(?:^|\s)(PartitionName|Default|Nodes)=(\S*)`)
+
+// Partition describes a SLURM partition
+type Partition struct {
+	Name    string
+	Nodes   []string // compacted node list
+	Default bool
+}
+
+// GetPartitions returns the SLURM partitions reported by "scontrol show partition"
+func GetPartitions(ctx context.Context) ([]*Partition, error) {
+	stdout, err := exec.Exec(ctx, "scontrol", []string{"show", "partition", "-o"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("stdout: %s", stdout.String())
+
+	return parsePartitions(stdout.String())
+}
+
+// parsePartitions parses "scontrol show partition -o" output
+func parsePartitions(data string) ([]*Partition, error) {
+	partitions := []*Partition{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "PartitionName=") {
+			continue
+		}
+
+		attrs := make(map[string]string)
+		for _, match := range partitionAttrRe.FindAllStringSubmatch(line, -1) {
+			attrs[match[1]] = match[2]
+		}
+
+		partitions = append(partitions, &Partition{
+			Name:    attrs["PartitionName"],
+			Nodes:   cluset.Compact(cluset.ExpandList(strings.Join(splitList(attrs["Nodes"]), ","))),
+			Default: attrs["Default"] == "YES",
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed scan output: %v", err)
+	}
+
+	return partitions, nil
+}
+
+// getAutoTopologies maps every non-empty partition without a topology to the tree, block or flat plugin:
+// block if all partition nodes are in blocks, tree if all partition nodes have tree data,
+// and flat otherwise. Topologies in the parameters take precedence over the discovered ones.
+func getAutoTopologies(ctx context.Context, root *topology.Vertex, params *Params, client Client) (map[string]*Topology, error) {
+	partitions, err := client.GetPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	topologies := make(map[string]*Topology)
+	overrides := make(map[string]bool)
+	hasDefault := false
+	for name, topo := range params.Topologies {
+		topologies[name] = topo
+		overrides[name] = true
+		if len(topo.Partition) != 0 {
+			overrides[topo.Partition] = true
+		}
+		hasDefault = hasDefault || topo.Default
+	}
+
+	treeNodes := getTreeNodes(root)
+	blockNodes := configNodes(root, topology.TopologyBlock)
+	blockSizes := getBlockSizes(params.BlockSizes)
+
+	for _, p := range partitions {
+		if overrides[p.Name] {
+			klog.V(4).Infof("Using topology override for partition %q", p.Name)
+			continue
+		}
+		if len(p.Nodes) == 0 {
+			klog.V(4).Infof("Skipping partition %q without nodes", p.Name)
+			continue
+		}
+
+		topo := &Topology{
+			Partition: p.Name,
+			Nodes:     p.Nodes,
+			Default:   p.Default && !hasDefault,
+		}
+		nodes := cluset.Expand(p.Nodes)
+		switch {
+		case containsAll(blockNodes, nodes):
+			topo.Plugin = topology.TopologyBlock
+			topo.BlockSizes = blockSizes
+		case containsAll(treeNodes, nodes):
+			topo.Plugin = topology.TopologyTree
+		default:
+			topo.Plugin = topology.TopologyFlat
+		}
+		klog.InfoS("Discovered partition topology", "partition", p.Name, "plugin", topo.Plugin)
+		topologies[p.Name] = topo
+	}
+
+	if len(topologies) == 0 {
+		return nil, fmt.Errorf("no partitions found")
+	}
+
+	return topologies, nil
+}
+
+func containsAll(set map[string]bool, items []string) bool {
+	for _, item := range items {
+		if !set[item] {
+			return false
+		}
+	}
+	return true
+}
+
+// getTreeNodes returns the set of node names connected to switches in the tree topology
+func getTreeNodes(root *topology.Vertex) map[string]bool {
+	nodes := configNodes(root, topology.TopologyTree)
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok {
+		if sw, ok := tree.Vertices[topology.NoTopology]; ok {
+			for _, w := range sw.Vertices {
+				delete(nodes, w.Name)
+			}
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const testPartitions = `PartitionName=batch AllowGroups=ALL AllowAccounts=ALL AllowQos=ALL AllocNodes=ALL Default=YES QoS=N/A DefaultTime=NONE MaxNodes=UNLIMITED Nodes=node[1-2],node4 PriorityJobFactor=1 State=UP TotalCPUs=384 TotalNodes=3
+PartitionName=train AllowGroups=ALL AllowAccounts=ALL AllowQos=ALL AllocNodes=ALL Default=NO QoS=N/A DefaultTime=NONE MaxNodes=UNLIMITED Nodes=node[1-3] PriorityJobFactor=1 State=UP TotalCPUs=384 TotalNodes=3
+PartitionName=cpu AllowGroups=ALL AllowAccounts=ALL AllowQos=ALL AllocNodes=ALL Default=NO QoS=N/A DefaultTime=NONE MaxNodes=UNLIMITED Nodes=cpu[1-2] PriorityJobFactor=1 State=UP TotalCPUs=128 TotalNodes=2
+PartitionName=empty AllowGroups=ALL AllowAccounts=ALL AllowQos=ALL AllocNodes=ALL Default=NO QoS=N/A DefaultTime=NONE MaxNodes=UNLIMITED Nodes=(null) PriorityJobFactor=1 State=UP TotalCPUs=0 TotalNodes=0
+`
+
+// testClient serves predefined SLURM data
+type testClient struct {
+	nodes      []*Node
+	partitions []*Partition
+}
+
+func (c *testClient) GetNodes(context.Context) ([]*Node, error) {
+	return c.nodes, nil
+}
+
+func (c *testClient) GetPartitions(context.Context) ([]*Partition, error) {
+	return c.partitions, nil
+}
+
+func (c *testClient) GetPartitionNodes(_ context.Context, partition string) ([]string, error) {
+	for _, p := range c.partitions {
+		if p.Name == partition {
+			return p.Nodes, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *testClient) Reconfigure(context.Context) error {
+	return nil
+}
+
+func TestParsePartitions(t *testing.T) {
+	partitions, err := parsePartitions(testPartitions)
+	require.NoError(t, err)
+
+	expected := []*Partition{
+		{Name: "batch", Nodes: []string{"node[1-2,4]"}, Default: true},
+		{Name: "train", Nodes: []string{"node[1-3]"}},
+		{Name: "cpu", Nodes: []string{"cpu[1-2]"}},
+		{Name: "empty"},
+	}
+	require.Equal(t, expected, partitions)
+}
+
+func getAutoPartitionsTestSet() *topology.Vertex {
+	return &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"sw1": {
+						ID: "sw1",
+						Vertices: map[string]*topology.Vertex{
+							"i1": {ID: "i1", Name: "node1"},
+							"i2": {ID: "i2", Name: "node2"},
+							"i3": {ID: "i3", Name: "node3"},
+						},
+					},
+					topology.NoTopology: {
+						ID: topology.NoTopology,
+						Vertices: map[string]*topology.Vertex{
+							"i4": {ID: "i4", Name: "node4"},
+						},
+					},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"nvl1": {
+						ID:   "block001",
+						Name: "nvl1",
+						Vertices: map[string]*topology.Vertex{
+							"node1": {ID: "i1", Name: "node1"},
+							"node2": {ID: "i2", Name: "node2"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetAutoTopologies(t *testing.T) {
+	ctx := context.TODO()
+	partitions, err := parsePartitions(testPartitions)
+	require.NoError(t, err)
+	partitions = append(partitions, &Partition{Name: "nvl", Nodes: []string{"node[1-2]"}})
+	client := &testClient{partitions: partitions}
+	root := getAutoPartitionsTestSet()
+
+	testCases := []struct {
+		name       string
+		params     *Params
+		topologies map[string]*Topology
+	}{
+		{
+			name: "Case 1: discovered partitions",
+			params: &Params{
+				BaseParams: BaseParams{BlockSizes: "2"},
+			},
+			topologies: map[string]*Topology{
+				"batch": {Partition: "batch", Plugin: topology.TopologyFlat, Nodes: []string{"node[1-2,4]"}, Default: true},
+				"train": {Partition: "train", Plugin: topology.TopologyTree, Nodes: []string{"node[1-3]"}},
+				"cpu":   {Partition: "cpu", Plugin: topology.TopologyFlat, Nodes: []string{"cpu[1-2]"}},
+				"nvl":   {Partition: "nvl", Plugin: topology.TopologyBlock, Nodes: []string{"node[1-2]"}, BlockSizes: []int{2}},
+			},
+		},
+		{
+			name: "Case 2: overrides",
+			params: &Params{
+				BaseParams: BaseParams{
+					Topologies: map[string]*Topology{
+						"nvl":     {Plugin: topology.TopologyTree, Nodes: []string{"node[1-2]"}},
+						"default": {Partition: "batch", Plugin: topology.TopologyTree, Default: true},
+					},
+				},
+			},
+			topologies: map[string]*Topology{
+				"default": {Partition: "batch", Plugin: topology.TopologyTree, Default: true},
+				"nvl":     {Plugin: topology.TopologyTree, Nodes: []string{"node[1-2]"}},
+				"train":   {Partition: "train", Plugin: topology.TopologyTree, Nodes: []string{"node[1-3]"}},
+				"cpu":     {Partition: "cpu", Plugin: topology.TopologyFlat, Nodes: []string{"cpu[1-2]"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			topologies, err := getAutoTopologies(ctx, root, tc.params, client)
+			require.NoError(t, err)
+			require.Equal(t, tc.topologies, topologies)
+		})
+	}
+}
+
+func TestGenerateOutputAutoPartitions(t *testing.T) {
+	ctx := context.TODO()
+	client := &testClient{
+		partitions: []*Partition{
+			{Name: "batch", Nodes: []string{"node[1-3]"}, Default: true},
+			{Name: "nvl", Nodes: []string{"node[1-2]"}},
+			{Name: "cpu", Nodes: []string{"cpu[1-2]"}},
+		},
+	}
+
+	expected := `# generated_at: 2026-01-02T03:04:05Z
+- topology: batch
+  clusterDefault: true
+  tree:
+    switches:
+        - switch: sw1
+          nodes: node[1-3]
+- topology: cpu
+  clusterDefault: false
+  flat: true
+- topology: nvl
+  clusterDefault: false
+  block:
+    blockSizes:
+        - 2
+    blocks:
+        - block: block0
+          nodes: node[1-2]
+`
+
+	root := getAutoPartitionsTestSet()
+	root.Metadata = map[string]string{topology.KeyGeneratedAt: "2026-01-02T03:04:05Z"}
+	out, httpErr := generateOutput(ctx, root, &Params{AutoPartitions: true}, client)
+	require.Nil(t, httpErr)
+	require.Equal(t, expected, string(out))
+
+	_, httpErr = generateOutput(ctx, root, &Params{AutoPartitions: true, BaseParams: BaseParams{Plugin: topology.TopologyTree}}, client)
+	require.EqualError(t, httpErr, "plugin and autoPartitions parameters are mutually exclusive")
+
+	_, httpErr = generateOutput(ctx, root, &Params{AutoPartitions: true}, &testClient{})
+	require.EqualError(t, httpErr, "no partitions found")
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"bytes"
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+// keepPlacement adds the nodes to the topology graph at their placement
+// in the topology config deployed at the given path
+func keepPlacement(root *topology.Vertex, path string, nodes []string) error {
+	if len(path) == 0 {
+		klog.Warningf("Cannot keep placement of power-saved nodes %v without topologyConfigPath", nodes)
+		return nil
+	}
+
+	data, err := readConfig(path)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		klog.Warningf("Cannot keep placement of power-saved nodes %v: no topology config in %q", nodes, path)
+		return nil
+	}
+
+	prev, err := translate.Parse(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse deployed topology config: %v", err)
+	}
+
+	if root.Vertices == nil {
+		root.Vertices = make(map[string]*topology.Vertex)
+	}
+
+	for _, node := range nodes {
+		kept := false
+		if tree, ok := prev.Vertices[topology.TopologyTree]; ok {
+			if switches := findNodePath(tree, node); switches != nil {
+				addTreeNode(root, switches, node)
+				kept = true
+			}
+		}
+		if blocks, ok := prev.Vertices[topology.TopologyBlock]; ok {
+			if block := findNodeBlock(blocks, node); block != nil {
+				kept = addBlockNode(root, block, node) || kept
+			}
+		}
+		if kept {
+			klog.V(4).Infof("Keeping placement of power-saved node %s", node)
+		} else {
+			klog.Warningf("No previous placement for power-saved node %s", node)
+		}
+	}
+
+	return nil
+}
+
+// findNodePath returns the chain of switches from the top level to the leaf switch of the node
+func findNodePath(v *topology.Vertex, node string) []*topology.Vertex {
+	for _, w := range v.Vertices {
+		if len(w.Vertices) == 0 {
+			if w.Name == node {
+				return []*topology.Vertex{v}
+			}
+			continue
+		}
+		if path := findNodePath(w, node); path != nil {
+			if len(v.ID) == 0 { // tree root
+				return path
+			}
+			return append([]*topology.Vertex{v}, path...)
+		}
+	}
+	return nil
+}
+
+// findSwitch returns the switch with the given ID
+func findSwitch(v *topology.Vertex, id string) *topology.Vertex {
+	for _, w := range v.Vertices {
+		if len(w.Vertices) == 0 {
+			continue
+		}
+		if w.ID == id {
+			return w
+		}
+		if sw := findSwitch(w, id); sw != nil {
+			return sw
+		}
+	}
+	return nil
+}
+
+// addTreeNode adds the node under the deepest existing switch of the chain,
+// recreating the missing switches below it
+func addTreeNode(root *topology.Vertex, switches []*topology.Vertex, node string) {
+	tree, ok := root.Vertices[topology.TopologyTree]
+	if !ok {
+		tree = &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		root.Vertices[topology.TopologyTree] = tree
+	}
+
+	parent, i := tree, len(switches)-1
+	for ; i >= 0; i-- {
+		if sw := findSwitch(tree, switches[i].ID); sw != nil {
+			parent = sw
+			break
+		}
+	}
+
+	for _, sw := range switches[i+1:] {
+		v := &topology.Vertex{
+			ID:       sw.ID,
+			Name:     sw.Name,
+			Vertices: make(map[string]*topology.Vertex),
+		}
+		parent.Vertices[v.ID] = v
+		parent = v
+	}
+
+	parent.Vertices[node] = &topology.Vertex{ID: node, Name: node}
+}
+
+// findNodeBlock returns the block containing the node
+func findNodeBlock(blocks *topology.Vertex, node string) *topology.Vertex {
+	for _, block := range blocks.Vertices {
+		for _, w := range block.Vertices {
+			if w.Name == node {
+				return block
+			}
+		}
+	}
+	return nil
+}
+
+// addBlockNode adds the node to the block with the same name, or the same ID for unnamed blocks.
+// It recreates the block if the ID is not taken.
+func addBlockNode(root *topology.Vertex, prev *topology.Vertex, node string) bool {
+	blocks, ok := root.Vertices[topology.TopologyBlock]
+	if !ok {
+		blocks = &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		root.Vertices[topology.TopologyBlock] = blocks
+	}
+
+	var target, sameID *topology.Vertex
+	for _, block := range blocks.Vertices {
+		if len(prev.Name) != 0 && block.Name == prev.Name {
+			target = block
+			break
+		}
+		if block.ID == prev.ID {
+			sameID = block
+		}
+	}
+
+	if target == nil && sameID != nil {
+		if len(prev.Name) != 0 {
+			klog.Warningf("Cannot restore block %q for power-saved node %s: block ID is taken", prev.ID, node)
+			return false
+		}
+		target = sameID
+	}
+
+	if target == nil {
+		key := prev.Name
+		if len(key) == 0 {
+			key = prev.ID
+		}
+		target = &topology.Vertex{
+			ID:       prev.ID,
+			Name:     prev.Name,
+			Vertices: make(map[string]*topology.Vertex),
+		}
+		blocks.Vertices[key] = target
+	}
+
+	target.Vertices[node] = &topology.Vertex{ID: node, Name: node}
+	return true
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -31,7 +32,6 @@ import (
 	"github.com/NVIDIA/topograph/internal/cluset"
 	"github.com/NVIDIA/topograph/internal/config"
 	"github.com/NVIDIA/topograph/internal/exec"
-	"github.com/NVIDIA/topograph/internal/files"
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/pkg/engines"
 	"github.com/NVIDIA/topograph/pkg/metrics"
@@ -48,7 +48,12 @@ const TopologyHeader = `
 
 const NAME = "slurm"
 
-type SlurmEngine struct{}
+type SlurmEngine struct {
+	params *Params
+	client Client
+	// powerSaved lists the power-saved nodes to keep in the last known placement
+	powerSaved []string
+}
 
 type BaseParams struct {
 	Plugin           string               `mapstructure:"plugin"`
@@ -70,20 +75,37 @@ type Params struct {
 	BaseParams     `mapstructure:",squash"`
 	TopoConfigPath string `mapstructure:"topologyConfigPath"`
 	Reconfigure    bool   `mapstructure:"reconfigure"`
+	// BackupCount (optional) specifies the number of timestamped topology config backups to keep
+	BackupCount int `mapstructure:"backupCount"`
+	// Validate (optional) enables node validation of the topology config before activation
+	Validate bool `mapstructure:"validate"`
+	// Backend (optional) specifies how to access the SLURM controller: "scontrol" (default) or "slurmrestd"
+	Backend string `mapstructure:"backend"`
+	// SlurmrestdURL specifies the slurmrestd endpoint for the "slurmrestd" backend
+	SlurmrestdURL string `mapstructure:"slurmrestdUrl"`
+	// SlurmrestdAPIVersion (optional) overrides the slurmrestd API version
+	SlurmrestdAPIVersion string `mapstructure:"slurmrestdApiVersion"`
+	// SlurmConfPath (optional) specifies slurm.conf to read the nodes and partitions from
+	// instead of querying the SLURM controller
+	SlurmConfPath string `mapstructure:"slurmConfPath"`
+	// AutoPartitions (optional) generates per-partition topologies for all partitions;
+	// the topologies parameter overrides the discovered ones
+	AutoPartitions bool `mapstructure:"autoPartitions"`
+	// NodeFilter (optional) selects the nodes for topology discovery
+	NodeFilter NodeFilter `mapstructure:"nodeFilter"`
+	// NodeFeaturesFile (optional) specifies the name of the node features include file
+	// written next to the topology config
+	NodeFeaturesFile string `mapstructure:"nodeFeaturesFile"`
+	// ExistingFeatures (optional) maps node lists to the features to keep in the node features file
+	ExistingFeatures map[string]string `mapstructure:"existingFeatures"`
 }
 
 type TopologyNodeFinder struct {
-	GetPartitionNodes func(context.Context, string, []any) (string, error)
-	Params            []any
-}
-
-type instanceMapper interface {
-	// Instances2NodeMap receives a list of SLURM node names and returns a map of
-	// the service provider assigned compute instance IDs to the node names
-	Instances2NodeMap(context.Context, []string) (map[string]string, error)
-	// GetInstancesRegions receives a list of SLURM node names and returns a map
-	// of node names to their deployed regions
-	GetInstancesRegions(context.Context, []string) (map[string]string, error)
+	// GetPartitionNodes returns the list of nodes in the partition
+	GetPartitionNodes func(context.Context, string, []any) ([]string, error)
+	// GetFakeNodes (optional) returns the fake nodes pool; defaults to scontrol lookup
+	GetFakeNodes func(context.Context) (string, error)
+	Params       []any
 }
 
 var partitionNodesRe *regexp.Regexp
@@ -96,92 +118,39 @@ func NamedLoader() (string, engines.Loader) {
 	return NAME, Loader
 }
 
-func Loader(_ context.Context, _ engines.Config) (engines.Engine, *httperr.Error) {
-	return &SlurmEngine{}, nil
-}
-
-func (eng *SlurmEngine) GetComputeInstances(ctx context.Context, environment engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
-	instanceMapper, ok := environment.(instanceMapper)
-	if !ok {
-		return nil, httperr.NewError(http.StatusBadRequest, "environment must implement instanceMapper")
-	}
-
-	nodes, err := GetNodeList(ctx)
+func Loader(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParams(cfg.Params)
 	if err != nil {
-		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
-	}
-
-	if len(nodes) == 0 {
-		return nil, nil
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
 
-	i2n, err := instanceMapper.Instances2NodeMap(ctx, nodes)
-	if err != nil {
-		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	if err = p.NodeFilter.Validate(); err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
-	klog.V(4).Infof("Detected instance map: %v", i2n)
 
-	nodeRegions, err := instanceMapper.GetInstancesRegions(ctx, nodes)
+	client, err := NewClient(p, cfg.Creds)
 	if err != nil {
-		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
-	}
-
-	return aggregateComputeInstances(i2n, nodeRegions), nil
-}
-
-func aggregateComputeInstances(i2n, nodeRegions map[string]string) []topology.ComputeInstances {
-	// regions maps region name to the corresponding index in "cis"
-	regions := make(map[string]int)
-	cis := []topology.ComputeInstances{}
-
-	for instance, node := range i2n {
-		region, ok := nodeRegions[node]
-		if !ok {
-			klog.Warningf("Failed to find region for node %s", node)
-			continue
-		}
-		indx, ok := regions[region]
-		if !ok {
-			indx = len(regions)
-			regions[region] = indx
-			cis = append(cis, topology.ComputeInstances{
-				Region:    region,
-				Instances: map[string]string{instance: node},
-			})
-		} else {
-			cis[indx].Instances[instance] = node
-		}
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
-	klog.V(4).Infof("Detected regions: %v", regions)
 
-	return cis
+	return &SlurmEngine{params: p, client: client}, nil
 }
 
-func GetNodeList(ctx context.Context) ([]string, error) {
-	stdout, err := exec.Exec(ctx, "scontrol", []string{"show", "nodes", "-o"}, nil)
+func (eng *SlurmEngine) GetComputeInstances(ctx context.Context, environment engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	nodeList, err := eng.client.GetNodes(ctx)
 	if err != nil {
-		return nil, err
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	klog.V(4).Infof("stdout: %s", stdout.String())
-
-	nodes := []string{}
-	scanner := bufio.NewScanner(strings.NewReader(stdout.String()))
-	for scanner.Scan() {
-		arr := strings.Split(scanner.Text(), " ")
-		if len(arr) > 0 {
-			line := arr[0]
-			if strings.HasPrefix(line, "NodeName=") {
-				nodes = append(nodes, line[9:])
-			}
+	nodes, powerSaved := eng.params.NodeFilter.Apply(nodeList)
+	if len(powerSaved) != 0 {
+		klog.Infof("Excluding power-saved nodes %v from discovery", powerSaved)
+		if eng.params.NodeFilter.PowerSaved == PowerSavedKeep {
+			eng.powerSaved = powerSaved
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed scan output: %v", err)
-	}
-
-	return nodes, nil
+	return engines.MapComputeInstances(ctx, environment, nodes)
 }
 
 func GetFakeNodes(ctx context.Context) (string, error) {
@@ -212,29 +181,32 @@ func parseFakeNodes(data string) (string, error) {
 	return "", fmt.Errorf("fake partition has no nodes")
 }
 
-func getPartitionNodes(ctx context.Context, partition string, _ []any) (string, error) {
+func getPartitionNodes(ctx context.Context, partition string) ([]string, error) {
 	args := []string{"show", "partition", partition}
 	stdout, err := exec.Exec(ctx, "scontrol", args, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	out := stdout.String()
-	return out, nil
+	klog.V(4).Infof("stdout: %s", out)
+
+	return ParsePartitionNodes(partition, out)
 }
 
 func GetPartitionNodes(ctx context.Context, partition string, f *TopologyNodeFinder) ([]string, error) {
 	if len(partition) == 0 {
 		return nil, fmt.Errorf("missing partition name")
 	}
-	out, err := f.GetPartitionNodes(ctx, partition, f.Params)
+	nodes, err := f.GetPartitionNodes(ctx, partition, f.Params)
 	if err != nil {
 		return nil, err
 	}
-	klog.V(4).Infof("GetPartitionNodes: %s", out)
-	return parsePartitionNodes(partition, out)
+	klog.V(4).Infof("GetPartitionNodes: %v", nodes)
+	return nodes, nil
 }
 
-func parsePartitionNodes(partition string, data string) ([]string, error) {
+// ParsePartitionNodes extracts the node list from "scontrol show partition" output
+func ParsePartitionNodes(partition string, data string) ([]string, error) {
 	match := partitionNodesRe.FindStringSubmatch(data)
 	if len(match) > 1 {
 		return cluset.Compact(cluset.ExpandList(match[1])), nil
@@ -244,7 +216,22 @@ func parsePartitionNodes(partition string, data string) ([]string, error) {
 }
 
 func (eng *SlurmEngine) GenerateOutput(ctx context.Context, tree *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
-	return GenerateOutput(ctx, tree, params)
+	p, err := getParams(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	if p.Reconfigure && len(eng.params.SlurmConfPath) != 0 {
+		return nil, httperr.NewError(http.StatusBadRequest, errConfReconfigure.Error())
+	}
+
+	if len(eng.powerSaved) != 0 {
+		if err = keepPlacement(tree, p.TopoConfigPath, eng.powerSaved); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return generateOutput(ctx, tree, p, eng.client)
 }
 
 func GenerateOutput(ctx context.Context, tree *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
@@ -256,13 +243,39 @@ func GenerateOutput(ctx context.Context, tree *topology.Vertex, params map[strin
 	return GenerateOutputParams(ctx, tree, p)
 }
 
+// GenerateOutputParams generates the topology config with the SLURM client
+// created from the parameters; it does not support credentials
 func GenerateOutputParams(ctx context.Context, root *topology.Vertex, params *Params) ([]byte, *httperr.Error) {
+	client, err := NewClient(params, nil)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return generateOutput(ctx, root, params, client)
+}
+
+func generateOutput(ctx context.Context, root *topology.Vertex, params *Params, client Client) ([]byte, *httperr.Error) {
+	if len(params.NodeFeaturesFile) != 0 && len(params.TopoConfigPath) == 0 {
+		return nil, httperr.NewError(http.StatusBadRequest, "nodeFeaturesFile parameter requires topologyConfigPath")
+	}
+
+	if params.AutoPartitions {
+		if len(params.Plugin) != 0 {
+			return nil, httperr.NewError(http.StatusBadRequest, "plugin and autoPartitions parameters are mutually exclusive")
+		}
+		topologies, err := getAutoTopologies(ctx, root, params, client)
+		if err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+		params.Topologies = topologies
+	}
+
 	// apply legacy default plugin value
 	if len(params.Plugin) == 0 && len(params.Topologies) == 0 {
 		params.Plugin = topology.TopologyTree
 	}
 
-	cfg, err := GetTranslateConfig(ctx, &params.BaseParams, &TopologyNodeFinder{GetPartitionNodes: getPartitionNodes})
+	cfg, err := GetTranslateConfig(ctx, &params.BaseParams, newTopologyNodeFinder(client))
 	if err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
@@ -291,17 +304,15 @@ func GenerateOutputParams(ctx context.Context, root *topology.Vertex, params *Pa
 		return data, nil
 	}
 
-	klog.Infof("Writing topology config in %q", path)
-	if err = files.Create(path, data); err != nil {
-		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
-	}
-	if params.Reconfigure {
-		if err = reconfigure(ctx); err != nil {
-			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
-		}
+	cfgs := []*configFile{{path: path, data: data, validate: true}}
+	if len(params.NodeFeaturesFile) != 0 {
+		cfgs = append(cfgs, &configFile{
+			path: filepath.Join(filepath.Dir(path), params.NodeFeaturesFile),
+			data: generateNodeFeatures(root, params.ExistingFeatures),
+		})
 	}
 
-	return []byte("OK\n"), nil
+	return deployConfig(ctx, params, client, cfgs...)
 }
 
 func GetTranslateConfig(ctx context.Context, params *BaseParams, f *TopologyNodeFinder) (*translate.Config, error) {
@@ -316,6 +327,11 @@ func GetTranslateConfig(ctx context.Context, params *BaseParams, f *TopologyNode
 		var err error
 		if len(params.FakeNodePool) > 0 {
 			fakeNodes = params.FakeNodePool
+		} else if f != nil && f.GetFakeNodes != nil {
+			fakeNodes, err = f.GetFakeNodes(ctx)
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			fakeNodes, err = GetFakeNodes(ctx)
 			if err != nil {
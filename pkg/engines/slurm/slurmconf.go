@@ -0,0 +1,297 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+)
+
+// SlurmConf holds the node, node set and partition definitions from slurm.conf
+type SlurmConf struct {
+	Nodes      []*Node
+	NodeSets   map[string][]string
+	Partitions []*Partition
+}
+
+// confAttrs are the "key=value" pairs of a slurm.conf line; keys are lower case
+type confAttrs map[string]string
+
+// ParseSlurmConf reads slurm.conf and the files it includes
+func ParseSlurmConf(path string) (*SlurmConf, error) {
+	lines, err := readSlurmConf(path, filepath.Dir(path), map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &SlurmConf{
+		Nodes:    []*Node{},
+		NodeSets: make(map[string][]string),
+	}
+
+	var (
+		nodeDefaults = confAttrs{}
+		partDefaults = confAttrs{}
+		nodeSets     []confAttrs
+		partitions   []confAttrs
+		known        = make(map[string]bool)
+	)
+
+	for _, line := range lines {
+		// only node, node set and partition definitions are relevant
+		key, _, _ := strings.Cut(line, "=")
+		switch strings.ToLower(key) {
+		case "nodename", "nodeset", "partitionname":
+		default:
+			continue
+		}
+
+		attrs, err := parseConfAttributes(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case len(attrs["nodename"]) != 0:
+			if strings.EqualFold(attrs["nodename"], "DEFAULT") {
+				maps.Copy(nodeDefaults, attrs)
+				continue
+			}
+			node := maps.Clone(nodeDefaults)
+			maps.Copy(node, attrs)
+			features, ok := node["features"]
+			if !ok {
+				features = node["feature"]
+			}
+			for _, name := range cluset.ExpandList(node["nodename"]) {
+				if known[name] {
+					klog.Warningf("Duplicate definition of node %s in slurm.conf", name)
+					continue
+				}
+				known[name] = true
+				conf.Nodes = append(conf.Nodes, &Node{
+					Name:     name,
+					State:    parseNodeState(node["state"]),
+					Features: splitList(features),
+					Gres:     splitGres(node["gres"]),
+				})
+			}
+
+		case len(attrs["nodeset"]) != 0:
+			nodeSets = append(nodeSets, attrs)
+
+		case len(attrs["partitionname"]) != 0:
+			if strings.EqualFold(attrs["partitionname"], "DEFAULT") {
+				maps.Copy(partDefaults, attrs)
+				continue
+			}
+			part := maps.Clone(partDefaults)
+			maps.Copy(part, attrs)
+			partitions = append(partitions, part)
+		}
+	}
+
+	// node sets refer to the nodes by name or by feature
+	for _, attrs := range nodeSets {
+		nodes := []string{}
+		if feature, ok := attrs["feature"]; ok {
+			for _, node := range conf.Nodes {
+				if slices.Contains(node.Features, feature) {
+					nodes = append(nodes, node.Name)
+				}
+			}
+		} else {
+			nodes = cluset.ExpandList(attrs["nodes"])
+		}
+		conf.NodeSets[attrs["nodeset"]] = nodes
+	}
+
+	for _, attrs := range partitions {
+		conf.Partitions = append(conf.Partitions, &Partition{
+			Name:    attrs["partitionname"],
+			Nodes:   cluset.Compact(conf.resolveNodes(attrs["nodes"])),
+			Default: strings.EqualFold(attrs["default"], "YES"),
+		})
+	}
+
+	return conf, nil
+}
+
+// resolveNodes expands the partition node list, which may refer to node sets or "ALL"
+func (conf *SlurmConf) resolveNodes(list string) []string {
+	if strings.EqualFold(list, "ALL") {
+		return nodeNames(conf.Nodes)
+	}
+
+	nodes := []string{}
+	for _, name := range cluset.ExpandList(list) {
+		if set, ok := conf.NodeSets[name]; ok {
+			nodes = append(nodes, set...)
+		} else {
+			nodes = append(nodes, name)
+		}
+	}
+	return nodes
+}
+
+// readSlurmConf returns the config lines of the file with resolved includes,
+// removed comments and joined continuation lines
+func readSlurmConf(path, baseDir string, visited map[string]bool) ([]string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if visited[absPath] {
+		return nil, fmt.Errorf("include loop in %q", path)
+	}
+	visited[absPath] = true
+	defer delete(visited, absPath)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q: %v", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	lines := []string{}
+	var current strings.Builder
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if indx := strings.Index(line, "#"); indx >= 0 {
+			line = line[:indx]
+		}
+		line = strings.TrimSpace(line)
+		if cont, ok := strings.CutSuffix(line, `\`); ok {
+			current.WriteString(cont)
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+		line = strings.TrimSpace(current.String())
+		current.Reset()
+		if len(line) == 0 {
+			continue
+		}
+
+		if fields := strings.Fields(line); len(fields) == 2 && strings.EqualFold(fields[0], "include") {
+			included, err := readSlurmConfInclude(fields[1], baseDir, visited)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, included...)
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %q: %v", path, err)
+	}
+
+	return lines, nil
+}
+
+// readSlurmConfInclude reads the files matching the include pattern;
+// relative paths are resolved against the directory of slurm.conf
+func readSlurmConfInclude(pattern, baseDir string, visited map[string]bool) ([]string, error) {
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(baseDir, pattern)
+	}
+
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include %q: %v", pattern, err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("included file %q not found", pattern)
+	}
+
+	lines := []string{}
+	for _, path := range paths {
+		included, err := readSlurmConf(path, baseDir, visited)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, included...)
+	}
+
+	return lines, nil
+}
+
+// parseConfAttributes splits a slurm.conf line into "key=value" pairs; values may be quoted
+func parseConfAttributes(line string) (confAttrs, error) {
+	attrs := confAttrs{}
+	var field strings.Builder
+	quoted := false
+	for _, c := range line + " " {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case (c == ' ' || c == '\t') && !quoted:
+			if field.Len() == 0 {
+				continue
+			}
+			key, val, ok := strings.Cut(field.String(), "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid attribute %q in slurm.conf line %q", field.String(), line)
+			}
+			attrs[strings.ToLower(key)] = val
+			field.Reset()
+		default:
+			field.WriteRune(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in slurm.conf line %q", line)
+	}
+	return attrs, nil
+}
+
+// confClient serves node and partition data from slurm.conf
+type confClient struct {
+	conf *SlurmConf
+}
+
+func newConfClient(path string) (*confClient, error) {
+	conf, err := ParseSlurmConf(path)
+	if err != nil {
+		return nil, err
+	}
+	return &confClient{conf: conf}, nil
+}
+
+func (c *confClient) GetNodes(context.Context) ([]*Node, error) {
+	return c.conf.Nodes, nil
+}
+
+func (c *confClient) GetPartitions(context.Context) ([]*Partition, error) {
+	return c.conf.Partitions, nil
+}
+
+func (c *confClient) GetPartitionNodes(_ context.Context, partition string) ([]string, error) {
+	for _, p := range c.conf.Partitions {
+		if p.Name == partition && len(p.Nodes) != 0 {
+			return p.Nodes, nil
+		}
+	}
+	return nil, fmt.Errorf("partition %q has no nodes", partition)
+}
+
+func (c *confClient) Reconfigure(context.Context) error {
+	return fmt.Errorf("reconfigure is not supported with slurmConfPath")
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const testSlurmConf = `# slurm.conf
+ClusterName=cluster
+SlurmctldHost=ctl
+SelectTypeParameters=CR_Core_Memory
+Include nodes.conf
+include conf.d/*.conf
+
+NodeSet=gpus Feature=gpu
+NodeSet=extra Nodes=cpu[3-4]
+
+PartitionName=DEFAULT State=UP MaxTime=INFINITE
+PartitionName=batch Nodes=gpus Default=YES
+PartitionName=cpu Nodes=cpu[1-2],extra
+PartitionName=all Nodes=ALL
+`
+
+const testNodesConf = `NodeName=DEFAULT CPUs=224 RealMemory=2000000 Features=gpu,h100 Gres=gpu:h100:8
+NodeName=node[1-3] \
+    State=CLOUD
+NodeName=node4 Features="gpu,b200" Gres=gpu:b200:8 # comment
+`
+
+const testCPUConf = `NodeName=cpu[1-4] CPUs=64 Features=cpu Gres=(null) State=FUTURE
+`
+
+func writeSlurmConf(t *testing.T) string {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "conf.d"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "slurm.conf"), []byte(testSlurmConf), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nodes.conf"), []byte(testNodesConf), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "conf.d", "cpu.conf"), []byte(testCPUConf), 0644))
+	return filepath.Join(dir, "slurm.conf")
+}
+
+func TestParseSlurmConf(t *testing.T) {
+	conf, err := ParseSlurmConf(writeSlurmConf(t))
+	require.NoError(t, err)
+
+	gpu := func(name string) *Node {
+		return &Node{Name: name, State: []string{"CLOUD"}, Features: []string{"gpu", "h100"}, Gres: []string{"gpu:h100:8"}}
+	}
+	cpu := func(name string) *Node {
+		return &Node{Name: name, State: []string{"FUTURE"}, Features: []string{"cpu"}}
+	}
+
+	expected := &SlurmConf{
+		Nodes: []*Node{
+			gpu("node1"), gpu("node2"), gpu("node3"),
+			{Name: "node4", State: []string{}, Features: []string{"gpu", "b200"}, Gres: []string{"gpu:b200:8"}},
+			cpu("cpu1"), cpu("cpu2"), cpu("cpu3"), cpu("cpu4"),
+		},
+		NodeSets: map[string][]string{
+			"gpus":  {"node1", "node2", "node3", "node4"},
+			"extra": {"cpu3", "cpu4"},
+		},
+		Partitions: []*Partition{
+			{Name: "batch", Nodes: []string{"node[1-4]"}, Default: true},
+			{Name: "cpu", Nodes: []string{"cpu[1-4]"}},
+			{Name: "all", Nodes: []string{"cpu[1-4]", "node[1-4]"}},
+		},
+	}
+	require.Equal(t, expected, conf)
+}
+
+func TestParseSlurmConfErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{
+			name:  "Case 1: missing include",
+			files: map[string]string{"slurm.conf": "Include missing.conf\n"},
+			err:   `included file "<dir>/missing.conf" not found`,
+		},
+		{
+			name: "Case 2: include loop",
+			files: map[string]string{
+				"slurm.conf": "Include a.conf\n",
+				"a.conf":     "Include slurm.conf\n",
+			},
+			err: `include loop in "<dir>/slurm.conf"`,
+		},
+		{
+			name:  "Case 3: unterminated quote",
+			files: map[string]string{"slurm.conf": "NodeName=n1 Features=\"gpu\n"},
+			err:   `unterminated quote in slurm.conf line "NodeName=n1 Features=\"gpu"`,
+		},
+		{
+			name:  "Case 4: invalid attribute",
+			files: map[string]string{"slurm.conf": "PartitionName=p1 Nodes\n"},
+			err:   `invalid attribute "Nodes" in slurm.conf line "PartitionName=p1 Nodes"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+			}
+			_, err := ParseSlurmConf(filepath.Join(dir, "slurm.conf"))
+			require.EqualError(t, err, strings.ReplaceAll(tc.err, "<dir>", dir))
+		})
+	}
+}
+
+func TestSlurmConfPath(t *testing.T) {
+	ctx := context.TODO()
+	path := writeSlurmConf(t)
+
+	_, httpErr := Loader(ctx, engines.Config{Params: map[string]any{"slurmConfPath": path, "backend": BackendScontrol}})
+	require.EqualError(t, httpErr, "slurmConfPath and backend parameters are mutually exclusive")
+	require.Equal(t, http.StatusBadRequest, httpErr.Code())
+
+	_, httpErr = Loader(ctx, engines.Config{Params: map[string]any{"slurmConfPath": path, "reconfigure": true}})
+	require.EqualError(t, httpErr, "slurmConfPath and reconfigure parameters are mutually exclusive")
+	require.Equal(t, http.StatusBadRequest, httpErr.Code())
+
+	params := map[string]any{
+		"slurmConfPath":  path,
+		"autoPartitions": true,
+		"nodeFilter": map[string]any{
+			"excludeStates": []string{"FUTURE"},
+		},
+	}
+	eng, httpErr := Loader(ctx, engines.Config{Params: params})
+	require.Nil(t, httpErr)
+
+	cis, httpErr := eng.GetComputeInstances(ctx, &testInstanceMapper{})
+	require.Nil(t, httpErr)
+	require.Len(t, cis, 1)
+	require.Len(t, cis[0].Instances, 4)
+
+	root := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"sw1": {
+						ID: "sw1",
+						Vertices: map[string]*topology.Vertex{
+							"i-node1": {ID: "i-node1", Name: "node1"},
+							"i-node2": {ID: "i-node2", Name: "node2"},
+							"i-node3": {ID: "i-node3", Name: "node3"},
+							"i-node4": {ID: "i-node4", Name: "node4"},
+						},
+					},
+				},
+			},
+		},
+		Metadata: map[string]string{topology.KeyGeneratedAt: "2026-01-02T03:04:05Z"},
+	}
+
+	expected := `# generated_at: 2026-01-02T03:04:05Z
+- topology: all
+  clusterDefault: false
+  flat: true
+- topology: batch
+  clusterDefault: true
+  tree:
+    switches:
+        - switch: sw1
+          nodes: node[1-4]
+- topology: cpu
+  clusterDefault: false
+  flat: true
+`
+	out, httpErr := eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, expected, string(out))
+}
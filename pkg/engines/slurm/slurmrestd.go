@@ -0,0 +1,207 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/internal/httpreq"
+)
+
+const (
+	// CredsToken is the engine credentials key for the slurmrestd JWT
+	CredsToken = "token"
+	// CredsUser is the optional engine credentials key for the SLURM user name
+	CredsUser = "user"
+
+	defaultSlurmrestdAPIVersion = "v0.0.40"
+
+	headerUserToken = "X-SLURM-USER-TOKEN"
+	headerUserName  = "X-SLURM-USER-NAME"
+)
+
+// restClient accesses the SLURM controller through the slurmrestd REST API
+type restClient struct {
+	url     string
+	version string
+	headers map[string]string
+}
+
+type restError struct {
+	Error       string `json:"error"`
+	ErrorNumber int    `json:"error_number"`
+	Description string `json:"description"`
+	Source      string `json:"source"`
+}
+
+type restResponse struct {
+	Errors []restError `json:"errors"`
+}
+
+type restNode struct {
+	Name     string   `json:"name"`
+	State    []string `json:"state"`
+	Features []string `json:"features"`
+	Gres     string   `json:"gres"`
+}
+
+type restNodesResponse struct {
+	restResponse
+	Nodes []restNode `json:"nodes"`
+}
+
+type restPartition struct {
+	Name  string `json:"name"`
+	Nodes struct {
+		Configured string `json:"configured"`
+	} `json:"nodes"`
+	Flags []string `json:"flags"`
+}
+
+type restPartitionsResponse struct {
+	restResponse
+	Partitions []restPartition `json:"partitions"`
+}
+
+func newRestClient(params *Params, creds map[string]string) (*restClient, error) {
+	if len(params.SlurmrestdURL) == 0 {
+		return nil, fmt.Errorf("missing slurmrestdUrl for %s backend", BackendSlurmrestd)
+	}
+
+	token := creds[CredsToken]
+	if len(token) == 0 {
+		return nil, fmt.Errorf("missing %q in credentials for %s backend", CredsToken, BackendSlurmrestd)
+	}
+
+	headers := map[string]string{
+		"Accept":        "application/json",
+		headerUserToken: token,
+	}
+	if user := creds[CredsUser]; len(user) != 0 {
+		headers[headerUserName] = user
+	}
+
+	version := params.SlurmrestdAPIVersion
+	if len(version) == 0 {
+		version = defaultSlurmrestdAPIVersion
+	}
+
+	return &restClient{
+		url:     params.SlurmrestdURL,
+		version: version,
+		headers: headers,
+	}, nil
+}
+
+func (c *restClient) GetNodes(ctx context.Context) ([]*Node, error) {
+	var resp restNodesResponse
+	if err := c.get(ctx, &resp, "nodes"); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*Node, 0, len(resp.Nodes))
+	for _, node := range resp.Nodes {
+		state := make([]string, 0, len(node.State))
+		for _, flag := range node.State {
+			state = append(state, strings.ToUpper(flag))
+		}
+		nodes = append(nodes, &Node{
+			Name:     node.Name,
+			State:    state,
+			Features: node.Features,
+			Gres:     splitGres(node.Gres),
+		})
+	}
+
+	return nodes, nil
+}
+
+func (c *restClient) GetPartitions(ctx context.Context) ([]*Partition, error) {
+	var resp restPartitionsResponse
+	if err := c.get(ctx, &resp, "partitions"); err != nil {
+		return nil, err
+	}
+
+	partitions := make([]*Partition, 0, len(resp.Partitions))
+	for _, p := range resp.Partitions {
+		partitions = append(partitions, &Partition{
+			Name:    p.Name,
+			Nodes:   cluset.Compact(cluset.ExpandList(p.Nodes.Configured)),
+			Default: slices.Contains(p.Flags, "DEFAULT"),
+		})
+	}
+
+	return partitions, nil
+}
+
+func (c *restClient) GetPartitionNodes(ctx context.Context, partition string) ([]string, error) {
+	var resp restPartitionsResponse
+	if err := c.get(ctx, &resp, "partition", partition); err != nil {
+		return nil, err
+	}
+
+	for _, p := range resp.Partitions {
+		if p.Name == partition && len(p.Nodes.Configured) != 0 {
+			return cluset.Compact(cluset.ExpandList(p.Nodes.Configured)), nil
+		}
+	}
+
+	return nil, fmt.Errorf("partition %q has no nodes", partition)
+}
+
+func (c *restClient) Reconfigure(ctx context.Context) error {
+	var resp restResponse
+	return c.get(ctx, &resp, "reconfigure")
+}
+
+// get sends GET request to the slurmrestd endpoint and decodes the response
+func (c *restClient) get(ctx context.Context, resp any, paths ...string) error {
+	paths = append([]string{"slurm", c.version}, paths...)
+	endpoint := strings.Join(paths, "/")
+
+	f := httpreq.GetRequestFunc(ctx, http.MethodGet, c.headers, nil, nil, c.url, paths...)
+	body, httpErr := httpreq.DoRequestWithRetries(f, false)
+	if httpErr != nil {
+		// slurmrestd describes failures in the response body
+		var errResp restResponse
+		if err := json.Unmarshal(body, &errResp); err == nil && len(errResp.Errors) != 0 {
+			return fmt.Errorf("slurmrestd %s: %s", endpoint, errorsToString(errResp.Errors))
+		}
+		return fmt.Errorf("slurmrestd %s: %v", endpoint, httpErr)
+	}
+	klog.V(4).Infof("slurmrestd %s: %s", endpoint, string(body))
+
+	if err := json.Unmarshal(body, resp); err != nil {
+		return fmt.Errorf("slurmrestd %s: failed to decode response: %v", endpoint, err)
+	}
+
+	var errResp restResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && len(errResp.Errors) != 0 {
+		return fmt.Errorf("slurmrestd %s: %s", endpoint, errorsToString(errResp.Errors))
+	}
+
+	return nil
+}
+
+func errorsToString(errs []restError) string {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msg := e.Description
+		if len(msg) == 0 {
+			msg = e.Error
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "; ")
+}
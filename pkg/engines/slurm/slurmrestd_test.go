@@ -0,0 +1,195 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const testToken = "test-jwt"
+
+// newSlurmrestd starts a local slurmrestd stand-in serving recorded responses.
+// The returned counter tracks reconfigure requests.
+func newSlurmrestd(t *testing.T) (*httptest.Server, *int) {
+	responses := map[string]string{
+		"/slurm/v0.0.40/nodes":             "nodes.json",
+		"/slurm/v0.0.40/partitions":        "partitions.json",
+		"/slurm/v0.0.40/partition/batch":   "partition_batch.json",
+		"/slurm/v0.0.40/partition/missing": "partition_missing.json",
+		"/slurm/v0.0.40/reconfigure":       "reconfigure.json",
+	}
+	reconfigured := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := responses[r.URL.Path]
+		switch {
+		case r.Header.Get(headerUserToken) != testToken:
+			w.WriteHeader(http.StatusUnauthorized)
+			file = "auth_error.json"
+		case !ok:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case r.URL.Path == "/slurm/v0.0.40/reconfigure":
+			reconfigured++
+		}
+		data, err := os.ReadFile(filepath.Join("../../../tests/output/slurmrestd", file))
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &reconfigured
+}
+
+func TestNewClient(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params *Params
+		creds  map[string]string
+		err    string
+	}{
+		{
+			name:   "Case 1: default backend",
+			params: &Params{},
+		},
+		{
+			name:   "Case 2: unsupported backend",
+			params: &Params{Backend: "bad"},
+			err:    `unsupported backend "bad"`,
+		},
+		{
+			name:   "Case 3: missing URL",
+			params: &Params{Backend: BackendSlurmrestd},
+			creds:  map[string]string{CredsToken: testToken},
+			err:    "missing slurmrestdUrl for slurmrestd backend",
+		},
+		{
+			name:   "Case 4: missing token",
+			params: &Params{Backend: BackendSlurmrestd, SlurmrestdURL: "http://localhost:6820"},
+			err:    `missing "token" in credentials for slurmrestd backend`,
+		},
+		{
+			name:   "Case 5: slurmrestd backend",
+			params: &Params{Backend: BackendSlurmrestd, SlurmrestdURL: "http://localhost:6820"},
+			creds:  map[string]string{CredsToken: testToken, CredsUser: "slurm"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewClient(tc.params, tc.creds)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, client)
+			}
+		})
+	}
+}
+
+func TestRestClient(t *testing.T) {
+	ctx := context.TODO()
+	srv, reconfigured := newSlurmrestd(t)
+
+	client, err := newRestClient(&Params{SlurmrestdURL: srv.URL}, map[string]string{CredsToken: testToken})
+	require.NoError(t, err)
+
+	nodeList, err := client.GetNodes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"node-1", "node-2", "node-3"}, nodeNames(nodeList))
+	require.Equal(t, &Node{
+		Name:     "node-3",
+		State:    []string{"IDLE", "DRAIN"},
+		Features: []string{"gpu"},
+		Gres:     []string{"gpu:h100:8"},
+	}, nodeList[2])
+
+	partitions, err := client.GetPartitions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*Partition{
+		{Name: "batch", Nodes: []string{"node-[1-3]"}, Default: true},
+		{Name: "cpu", Nodes: []string{"cpu[1-2]"}},
+	}, partitions)
+
+	nodes, err := client.GetPartitionNodes(ctx, "batch")
+	require.NoError(t, err)
+	require.Equal(t, []string{"node-[1-3]"}, nodes)
+
+	_, err = client.GetPartitionNodes(ctx, "missing")
+	require.EqualError(t, err, "slurmrestd slurm/v0.0.40/partition/missing: Unable to find partition missing")
+
+	require.NoError(t, client.Reconfigure(ctx))
+	require.Equal(t, 1, *reconfigured)
+
+	client, err = newRestClient(&Params{SlurmrestdURL: srv.URL}, map[string]string{CredsToken: "bad"})
+	require.NoError(t, err)
+	_, err = client.GetNodes(ctx)
+	require.EqualError(t, err, "slurmrestd slurm/v0.0.40/nodes: Authentication failure")
+}
+
+func TestSlurmrestdBackend(t *testing.T) {
+	ctx := context.TODO()
+	srv, reconfigured := newSlurmrestd(t)
+	path := filepath.Join(t.TempDir(), "topology.conf")
+
+	params := map[string]any{
+		"backend":            BackendSlurmrestd,
+		"slurmrestdUrl":      srv.URL,
+		"topologyConfigPath": path,
+		"reconfigure":        true,
+		"validate":           true,
+		"topologies": map[string]any{
+			"batch": map[string]any{
+				"plugin":         topology.TopologyTree,
+				"partition":      "batch",
+				"clusterDefault": true,
+			},
+		},
+	}
+
+	eng, httpErr := Loader(ctx, engines.Config{
+		Creds:  map[string]string{CredsToken: testToken},
+		Params: params,
+	})
+	require.Nil(t, httpErr)
+
+	root := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"S1": {
+						ID: "S1",
+						Vertices: map[string]*topology.Vertex{
+							"i1": {ID: "i1", Name: "node-1"},
+							"i2": {ID: "i2", Name: "node-2"},
+							"i3": {ID: "i3", Name: "node-3"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	out, httpErr := eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(out))
+	require.Equal(t, 1, *reconfigured)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "nodes: node-[1-3]")
+}
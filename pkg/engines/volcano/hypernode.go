@@ -0,0 +1,190 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// HyperNode member types
+const (
+	MemberNode      = "Node"
+	MemberHyperNode = "HyperNode"
+
+	blockPrefix = "nvl-"
+)
+
+var (
+	HyperNodeGVR = schema.GroupVersionResource{Group: "topology.volcano.sh", Version: "v1alpha1", Resource: "hypernodes"}
+
+	invalidNameRe = regexp.MustCompile(`[^a-z0-9.-]+`)
+)
+
+// hyperNode is a network topology domain with node or HyperNode members
+type hyperNode struct {
+	name    string
+	tier    int
+	members []member
+}
+
+type member struct {
+	typ  string
+	name string
+}
+
+// blockIndex maps the nodes to the NVLink block HyperNodes containing them
+type blockIndex struct {
+	blocks map[string]string
+	sizes  map[string]int
+}
+
+// getHyperNodes converts the topology into HyperNodes, from the tightest locality at the lowest tier up.
+// NVLink blocks become HyperNodes at the block tier, which defaults to tier 1.
+// The tree switches become HyperNodes with leaf switches at the tier above the blocks, or at tier 1
+// without blocks, and their parents one tier above their highest child.
+// A switch connected to all the nodes of a block lists the block HyperNode instead of the nodes.
+func getHyperNodes(root *topology.Vertex, blockTier int) ([]*hyperNode, error) {
+	hyperNodes := []*hyperNode{}
+	leafTier := 1
+	index := &blockIndex{blocks: make(map[string]string), sizes: make(map[string]int)}
+
+	if blocks, ok := root.Vertices[topology.TopologyBlock]; ok && len(blocks.Vertices) != 0 {
+		if blockTier <= 0 {
+			blockTier = 1
+		}
+		leafTier = 2
+		for _, block := range blocks.Vertices {
+			name := block.Name
+			if len(name) == 0 {
+				name = block.ID
+			}
+			hn := &hyperNode{name: hyperNodeName(blockPrefix + name), tier: blockTier}
+			for _, node := range block.Vertices {
+				hn.members = append(hn.members, member{typ: MemberNode, name: node.Name})
+				index.blocks[node.Name] = hn.name
+			}
+			index.sizes[hn.name] = len(block.Vertices)
+			hn.sortMembers()
+			hyperNodes = append(hyperNodes, hn)
+		}
+	}
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok {
+		for _, v := range tree.Vertices {
+			if v.ID == topology.NoTopology || len(v.Vertices) == 0 {
+				continue
+			}
+			if len(index.sizes) != 0 && blockTier >= leafTier {
+				return nil, fmt.Errorf("blockTier %d must be below the leaf switch tier %d", blockTier, leafTier)
+			}
+			addSwitch(v, leafTier, index, &hyperNodes)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, hn := range hyperNodes {
+		if names[hn.name] {
+			return nil, fmt.Errorf("duplicate HyperNode name %q", hn.name)
+		}
+		names[hn.name] = true
+	}
+
+	slices.SortFunc(hyperNodes, func(a, b *hyperNode) int { return strings.Compare(a.name, b.name) })
+
+	return hyperNodes, nil
+}
+
+// addSwitch adds the HyperNodes of the switch subtree and returns the switch HyperNode.
+// Switches connected only to nodes are leaf switches at the leaf tier.
+// The nodes of a block connected entirely to the switch are replaced by the block HyperNode;
+// the nodes of a block spanning several switches stay direct members.
+func addSwitch(v *topology.Vertex, leafTier int, index *blockIndex, hyperNodes *[]*hyperNode) *hyperNode {
+	hn := &hyperNode{name: hyperNodeName(v.ID), tier: leafTier}
+	nodes := make(map[string][]string)
+	for _, w := range v.Vertices {
+		if len(w.Vertices) == 0 {
+			block := index.blocks[w.Name]
+			nodes[block] = append(nodes[block], w.Name)
+			continue
+		}
+		child := addSwitch(w, leafTier, index, hyperNodes)
+		hn.members = append(hn.members, member{typ: MemberHyperNode, name: child.name})
+		hn.tier = max(hn.tier, child.tier+1)
+	}
+
+	for block, names := range nodes {
+		if len(block) != 0 && len(names) == index.sizes[block] {
+			hn.members = append(hn.members, member{typ: MemberHyperNode, name: block})
+			continue
+		}
+		for _, name := range names {
+			hn.members = append(hn.members, member{typ: MemberNode, name: name})
+		}
+	}
+
+	hn.sortMembers()
+	*hyperNodes = append(*hyperNodes, hn)
+
+	return hn
+}
+
+func (hn *hyperNode) sortMembers() {
+	slices.SortFunc(hn.members, func(a, b member) int {
+		if c := strings.Compare(a.typ, b.typ); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name, b.name)
+	})
+}
+
+// hyperNodeName converts the switch or block name into a valid object name
+func hyperNodeName(name string) string {
+	str := strings.Trim(invalidNameRe.ReplaceAllString(strings.ToLower(name), "-"), ".-")
+	if len(str) != 0 && len(str) <= 253 {
+		return str
+	}
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("x%x", h.Sum64())
+}
+
+// toUnstructured returns the HyperNode object
+func (hn *hyperNode) toUnstructured(labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": HyperNodeGVR.GroupVersion().String(),
+		"kind":       "HyperNode",
+		"metadata":   map[string]any{"name": hn.name},
+		"spec":       hn.spec(),
+	}}
+	obj.SetLabels(maps.Clone(labels))
+	return obj
+}
+
+func (hn *hyperNode) spec() map[string]any {
+	members := make([]any, 0, len(hn.members))
+	for _, m := range hn.members {
+		members = append(members, map[string]any{
+			"type": m.typ,
+			"selector": map[string]any{
+				"exactMatch": map[string]any{"name": m.name},
+			},
+		})
+	}
+	return map[string]any{
+		"tier":    int64(hn.tier),
+		"members": members,
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func getTestGraph() *topology.Vertex {
+	return &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"core": {
+						ID: "core",
+						Vertices: map[string]*topology.Vertex{
+							"Spine_1": {
+								ID: "Spine_1",
+								Vertices: map[string]*topology.Vertex{
+									"leaf1": {
+										ID: "leaf1",
+										Vertices: map[string]*topology.Vertex{
+											"i1": {ID: "i1", Name: "node1"},
+											"i2": {ID: "i2", Name: "node2"},
+										},
+									},
+								},
+							},
+							"leaf2": {
+								ID:       "leaf2",
+								Vertices: map[string]*topology.Vertex{"i3": {ID: "i3", Name: "node3"}},
+							},
+							"i4": {ID: "i4", Name: "node4"},
+						},
+					},
+					topology.NoTopology: {
+						ID:       topology.NoTopology,
+						Vertices: map[string]*topology.Vertex{"i5": {ID: "i5", Name: "node5"}},
+					},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"nvl1": {
+						ID:   "block001",
+						Name: "nvl1",
+						Vertices: map[string]*topology.Vertex{
+							"node1": {ID: "i1", Name: "node1"},
+							"node2": {ID: "i2", Name: "node2"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetHyperNodes(t *testing.T) {
+	node := func(name string) member { return member{typ: MemberNode, name: name} }
+	hyper := func(name string) member { return member{typ: MemberHyperNode, name: name} }
+
+	testCases := []struct {
+		name       string
+		root       *topology.Vertex
+		blockTier  int
+		hyperNodes []*hyperNode
+		err        string
+	}{
+		{
+			name: "Case 1: tree and blocks",
+			root: getTestGraph(),
+			hyperNodes: []*hyperNode{
+				{name: "core", tier: 4, members: []member{hyper("leaf2"), hyper("spine-1"), node("node4")}},
+				{name: "leaf1", tier: 2, members: []member{hyper("nvl-nvl1")}},
+				{name: "leaf2", tier: 2, members: []member{node("node3")}},
+				{name: "nvl-nvl1", tier: 1, members: []member{node("node1"), node("node2")}},
+				{name: "spine-1", tier: 3, members: []member{hyper("leaf1")}},
+			},
+		},
+		{
+			name: "Case 1.2: block spanning leaf switches",
+			root: func() *topology.Vertex {
+				root := getTestGraph()
+				root.Vertices[topology.TopologyBlock].Vertices["nvl1"].Vertices["node3"] = &topology.Vertex{ID: "i3", Name: "node3"}
+				return root
+			}(),
+			hyperNodes: []*hyperNode{
+				{name: "core", tier: 4, members: []member{hyper("leaf2"), hyper("spine-1"), node("node4")}},
+				{name: "leaf1", tier: 2, members: []member{node("node1"), node("node2")}},
+				{name: "leaf2", tier: 2, members: []member{node("node3")}},
+				{name: "nvl-nvl1", tier: 1, members: []member{node("node1"), node("node2"), node("node3")}},
+				{name: "spine-1", tier: 3, members: []member{hyper("leaf1")}},
+			},
+		},
+		{
+			name: "Case 1.1: tree without blocks",
+			root: &topology.Vertex{Vertices: map[string]*topology.Vertex{topology.TopologyTree: getTestGraph().Vertices[topology.TopologyTree]}},
+			hyperNodes: []*hyperNode{
+				{name: "core", tier: 3, members: []member{hyper("leaf2"), hyper("spine-1"), node("node4")}},
+				{name: "leaf1", tier: 1, members: []member{node("node1"), node("node2")}},
+				{name: "leaf2", tier: 1, members: []member{node("node3")}},
+				{name: "spine-1", tier: 2, members: []member{hyper("leaf1")}},
+			},
+		},
+		{
+			name:      "Case 2: block tier",
+			root:      &topology.Vertex{Vertices: map[string]*topology.Vertex{topology.TopologyBlock: getTestGraph().Vertices[topology.TopologyBlock]}},
+			blockTier: 2,
+			hyperNodes: []*hyperNode{
+				{name: "nvl-nvl1", tier: 2, members: []member{node("node1"), node("node2")}},
+			},
+		},
+		{
+			name:      "Case 2.1: block tier not below the leaf switches",
+			root:      getTestGraph(),
+			blockTier: 2,
+			err:       "blockTier 2 must be below the leaf switch tier 2",
+		},
+		{
+			name: "Case 3: duplicate names",
+			root: &topology.Vertex{
+				Vertices: map[string]*topology.Vertex{
+					topology.TopologyTree: {
+						Vertices: map[string]*topology.Vertex{
+							"SW1": {ID: "SW1", Vertices: map[string]*topology.Vertex{"i1": {ID: "i1", Name: "node1"}}},
+							"sw1": {ID: "sw1", Vertices: map[string]*topology.Vertex{"i2": {ID: "i2", Name: "node2"}}},
+						},
+					},
+				},
+			},
+			err: `duplicate HyperNode name "sw1"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hyperNodes, err := getHyperNodes(tc.root, tc.blockTier)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.hyperNodes, hyperNodes)
+			}
+		})
+	}
+}
+
+func TestHyperNodeTiers(t *testing.T) {
+	hyperNodes, err := getHyperNodes(getTestGraph(), 0)
+	require.NoError(t, err)
+
+	tiers := make(map[string]int)
+	for _, hn := range hyperNodes {
+		tiers[hn.name] = hn.tier
+	}
+
+	// the NVLink blocks are the tightest locality, below the leaf switches
+	require.Less(t, tiers["nvl-nvl1"], tiers["leaf1"])
+	// every switch is above its child switches
+	require.Less(t, tiers["leaf1"], tiers["spine-1"])
+	require.Less(t, tiers["spine-1"], tiers["core"])
+	require.Less(t, tiers["leaf2"], tiers["core"])
+}
+
+func TestHyperNodeName(t *testing.T) {
+	require.Equal(t, "spine-1.a", hyperNodeName("_Spine_1.A_"))
+	require.Equal(t, "x9532907b5e074e3", hyperNodeName("__"))
+}
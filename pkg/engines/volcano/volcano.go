@@ -0,0 +1,175 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/k8s"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const NAME = "volcano"
+
+// ownerLabels mark the HyperNodes managed by topograph
+var ownerLabels = map[string]string{topology.KeyConfigMapTopologyManagedBy: "topograph"}
+
+type VolcanoEngine struct {
+	// nodes discovers the compute instances of the Kubernetes nodes
+	nodes  engines.Engine
+	client dynamic.Interface
+	params *Params
+}
+
+type Params struct {
+	// NodeSelector (optional) specifies nodes participating in the topology
+	NodeSelector map[string]string `mapstructure:"nodeSelector"`
+	// BlockTier (optional) specifies the tier of the NVLink block HyperNodes;
+	// defaults to tier 1, below the leaf switches
+	BlockTier int `mapstructure:"blockTier"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(ctx context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(cfg.Params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	nodes, httpErr := k8s.Loader(ctx, engines.Config{Params: map[string]any{"nodeSelector": p.NodeSelector}})
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	return &VolcanoEngine{
+		nodes:  nodes,
+		client: client,
+		params: p,
+	}, nil
+}
+
+func getParameters(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if p.BlockTier < 0 {
+		return nil, fmt.Errorf("invalid blockTier %d", p.BlockTier)
+	}
+
+	return p, nil
+}
+
+func (eng *VolcanoEngine) GetComputeInstances(ctx context.Context, environment engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	return eng.nodes.GetComputeInstances(ctx, environment)
+}
+
+func (eng *VolcanoEngine) GenerateOutput(ctx context.Context, root *topology.Vertex, _ map[string]any) ([]byte, *httperr.Error) {
+	hyperNodes, err := getHyperNodes(root, eng.params.BlockTier)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	changed, err := reconcile(ctx, eng.client, hyperNodes)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	if !changed {
+		metrics.AddUnchangedTopology(NAME)
+		return []byte(engines.UnchangedResult), nil
+	}
+
+	return []byte("OK\n"), nil
+}
+
+// reconcile creates and updates the HyperNodes, and deletes the stale topograph-owned HyperNodes.
+// It returns false if the HyperNodes are unchanged.
+func reconcile(ctx context.Context, client dynamic.Interface, hyperNodes []*hyperNode) (bool, error) {
+	res := client.Resource(HyperNodeGVR)
+	list, err := res.List(ctx, metav1.ListOptions{LabelSelector: labels.Set(ownerLabels).String()})
+	if err != nil {
+		return false, fmt.Errorf("failed to list HyperNodes: %v", err)
+	}
+
+	current := make(map[string]*unstructured.Unstructured)
+	for i := range list.Items {
+		current[list.Items[i].GetName()] = &list.Items[i]
+	}
+
+	changed := false
+	for _, hn := range hyperNodes {
+		obj, ok := current[hn.name]
+		if !ok {
+			klog.V(4).Infof("Creating HyperNode %s", hn.name)
+			if _, err = res.Create(ctx, hn.toUnstructured(ownerLabels), metav1.CreateOptions{}); err != nil {
+				if errors.IsAlreadyExists(err) {
+					return false, fmt.Errorf("HyperNode %s exists and is not managed by topograph", hn.name)
+				}
+				return false, fmt.Errorf("failed to create HyperNode %s: %v", hn.name, err)
+			}
+			changed = true
+			continue
+		}
+		delete(current, hn.name)
+
+		spec := hn.spec()
+		if reflect.DeepEqual(obj.Object["spec"], spec) {
+			continue
+		}
+
+		klog.V(4).Infof("Updating HyperNode %s", hn.name)
+		obj.Object["spec"] = spec
+		if _, err = res.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+			return false, fmt.Errorf("failed to update HyperNode %s: %v", hn.name, err)
+		}
+		changed = true
+	}
+
+	// the remaining HyperNodes are stale
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		klog.V(4).Infof("Deleting stale HyperNode %s", name)
+		if err = res.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete HyperNode %s: %v", name, err)
+		}
+		changed = true
+	}
+
+	klog.Infof("Reconciled %d HyperNodes", len(hyperNodes))
+
+	return changed, nil
+}
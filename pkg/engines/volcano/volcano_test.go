@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.TODO()
+
+	unmanaged := (&hyperNode{name: "manual", tier: 1, members: []member{{typ: MemberNode, name: "node9"}}}).toUnstructured(nil)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{HyperNodeGVR: "HyperNodeList"}, unmanaged)
+	res := client.Resource(HyperNodeGVR)
+
+	getSpec := func(name string) map[string]any {
+		obj, err := res.Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+		require.NoError(t, err)
+		return spec
+	}
+	listNames := func() []string {
+		list, err := res.List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		names := []string{}
+		for _, item := range list.Items {
+			names = append(names, item.GetName())
+		}
+		return names
+	}
+
+	// create
+	hyperNodes, err := getHyperNodes(getTestGraph(), 0)
+	require.NoError(t, err)
+	changed, err := reconcile(ctx, client, hyperNodes)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.ElementsMatch(t, []string{"core", "leaf1", "leaf2", "manual", "nvl-nvl1", "spine-1"}, listNames())
+	require.Equal(t, map[string]any{
+		"tier": int64(2),
+		"members": []any{
+			map[string]any{"type": "Node", "selector": map[string]any{"exactMatch": map[string]any{"name": "node3"}}},
+		},
+	}, getSpec("leaf2"))
+
+	// unchanged
+	changed, err = reconcile(ctx, client, hyperNodes)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// update and delete stale
+	root := getTestGraph()
+	delete(root.Vertices, topology.TopologyBlock)
+	core := root.Vertices[topology.TopologyTree].Vertices["core"]
+	core.Vertices["leaf2"].Vertices["i4"] = core.Vertices["i4"]
+	delete(core.Vertices, "i4")
+	hyperNodes, err = getHyperNodes(root, 0)
+	require.NoError(t, err)
+	changed, err = reconcile(ctx, client, hyperNodes)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.ElementsMatch(t, []string{"core", "leaf1", "leaf2", "manual", "spine-1"}, listNames())
+	require.Len(t, getSpec("leaf2")["members"], 2)
+	require.Len(t, getSpec("core")["members"], 2)
+
+	// conflict with unmanaged HyperNode
+	_, err = reconcile(ctx, client, []*hyperNode{{name: "manual", tier: 1}})
+	require.EqualError(t, err, "HyperNode manual exists and is not managed by topograph")
+}
+
+func TestGetParameters(t *testing.T) {
+	p, err := getParameters(map[string]any{"nodeSelector": map[string]any{"a": "b"}, "blockTier": 3})
+	require.NoError(t, err)
+	require.Equal(t, &Params{NodeSelector: map[string]string{"a": "b"}, BlockTier: 3}, p)
+
+	_, err = getParameters(map[string]any{"blockTier": -1})
+	require.EqualError(t, err, "invalid blockTier -1")
+}
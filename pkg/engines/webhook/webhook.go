@@ -0,0 +1,130 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/internal/httpreq"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/json"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "webhook"
+
+	credToken    = "token"
+	credUsername = "username"
+	credPassword = "password"
+)
+
+type WebhookEngine struct {
+	creds map[string]string
+}
+
+type Params struct {
+	// URL specifies the endpoint receiving the topology document
+	URL string `mapstructure:"url"`
+	// Headers (optional) specifies additional HTTP headers
+	Headers map[string]string `mapstructure:"headers"`
+	// InsecureSkipVerify (optional) disables the TLS certificate verification
+	InsecureSkipVerify bool `mapstructure:"insecureSkipVerify"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, cfg engines.Config) (engines.Engine, *httperr.Error) {
+	if _, err := getParams(cfg.Params); err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+	if _, err := getAuthorization(cfg.Creds); err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &WebhookEngine{creds: cfg.Creds}, nil
+}
+
+func getParams(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if len(p.URL) == 0 {
+		return nil, fmt.Errorf("missing url parameter")
+	}
+
+	return p, nil
+}
+
+// getAuthorization returns the Authorization header value from the engine credentials, if any
+func getAuthorization(creds map[string]string) (string, error) {
+	token, username := creds[credToken], creds[credUsername]
+	switch {
+	case len(token) != 0 && len(username) != 0:
+		return "", fmt.Errorf("token and username are mutually exclusive")
+	case len(token) != 0:
+		return "Bearer " + token, nil
+	case len(username) != 0:
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+creds[credPassword])), nil
+	default:
+		return "", nil
+	}
+}
+
+// GetComputeInstances is not supported: the nodes are provided in the request
+func (eng *WebhookEngine) GetComputeInstances(_ context.Context, _ engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, engines.ErrNodesRequired(NAME)
+}
+
+func (eng *WebhookEngine) GenerateOutput(ctx context.Context, root *topology.Vertex, params map[string]any) ([]byte, *httperr.Error) {
+	p, err := getParams(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return GenerateOutputParams(ctx, root, p, eng.creds)
+}
+
+// GenerateOutputParams sends the topology document to the webhook,
+// authenticated with the token or the username and password of the engine credentials
+func GenerateOutputParams(ctx context.Context, root *topology.Vertex, params *Params, creds map[string]string) ([]byte, *httperr.Error) {
+	auth, err := getAuthorization(creds)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	data, err := json.ToDocument(root).Marshal()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	maps.Copy(headers, params.Headers)
+	if len(auth) != 0 {
+		headers["Authorization"] = auth
+	}
+
+	// the request is sent once: the server retries the engine output on retriable status codes
+	f := httpreq.GetRequestFunc(ctx, http.MethodPost, headers, nil, data, params.URL)
+	if _, _, httpErr := httpreq.DoRequest(f, params.InsecureSkipVerify); httpErr != nil {
+		return nil, httperr.NewError(httpErr.Code(), fmt.Sprintf("webhook request failed: %v", httpErr))
+	}
+	klog.Infof("Sent topology document to %s", params.URL)
+
+	return []byte("OK\n"), nil
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/json"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestGetParams(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		p      *Params
+		err    string
+	}{
+		{
+			name: "Case 1: missing url",
+			err:  "missing url parameter",
+		},
+		{
+			name:   "Case 2: url and headers",
+			params: map[string]any{"url": "http://cmdb", "headers": map[string]any{"X-Cluster": "c1"}},
+			p:      &Params{URL: "http://cmdb", Headers: map[string]string{"X-Cluster": "c1"}},
+		},
+		{
+			name:   "Case 3: authentication parameters are ignored",
+			params: map[string]any{"url": "http://cmdb", "token": "secret", "username": "user", "password": "pass"},
+			p:      &Params{URL: "http://cmdb"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := getParams(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.p, p)
+			}
+		})
+	}
+}
+
+func TestGetAuthorization(t *testing.T) {
+	testCases := []struct {
+		name  string
+		creds map[string]string
+		auth  string
+		err   string
+	}{
+		{
+			name: "Case 1: no credentials",
+		},
+		{
+			name:  "Case 2: token",
+			creds: map[string]string{"token": "secret"},
+			auth:  "Bearer secret",
+		},
+		{
+			name:  "Case 3: username and password",
+			creds: map[string]string{"username": "admin", "password": "secret"},
+			auth:  "Basic YWRtaW46c2VjcmV0",
+		},
+		{
+			name:  "Case 4: token and username",
+			creds: map[string]string{"token": "secret", "username": "admin"},
+			err:   "token and username are mutually exclusive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			auth, err := getAuthorization(tc.creds)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.auth, auth)
+			}
+		})
+	}
+}
+
+func TestWebhookEngine(t *testing.T) {
+	ctx := context.TODO()
+	root, _ := translate.GetTreeTestSet(false)
+	expected, err := json.ToDocument(root).Marshal()
+	require.NoError(t, err)
+
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch r.URL.Path {
+		case "/topology":
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "admin" || pass != "secret" || r.Header.Get("X-Cluster") != "c1" ||
+				r.Header.Get("Content-Type") != "application/json" || r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if body, _ := io.ReadAll(r.Body); string(body) != string(expected) {
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		case "/bearer":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+		}
+	}))
+	defer srv.Close()
+
+	_, httpErr := Loader(ctx, engines.Config{})
+	require.EqualError(t, httpErr, "missing url parameter")
+
+	_, httpErr = Loader(ctx, engines.Config{Params: map[string]any{"url": srv.URL}, Creds: map[string]string{"token": "token", "username": "admin"}})
+	require.EqualError(t, httpErr, "token and username are mutually exclusive")
+
+	params := map[string]any{"url": srv.URL + "/topology", "headers": map[string]any{"X-Cluster": "c1"}}
+	eng, httpErr := Loader(ctx, engines.Config{Params: params, Creds: map[string]string{"username": "admin", "password": "secret"}})
+	require.Nil(t, httpErr)
+
+	_, httpErr = eng.GetComputeInstances(ctx, nil)
+	require.EqualError(t, httpErr, "webhook engine requires the nodes in the request")
+
+	// the request is not retried by the engine
+	_, httpErr = eng.GenerateOutput(ctx, root, params)
+	require.Equal(t, http.StatusServiceUnavailable, httpErr.Code())
+	require.Equal(t, 1, attempts)
+
+	data, httpErr := eng.GenerateOutput(ctx, root, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(data))
+	require.Equal(t, 2, attempts)
+
+	eng, httpErr = Loader(ctx, engines.Config{Params: map[string]any{"url": srv.URL}, Creds: map[string]string{"token": "token"}})
+	require.Nil(t, httpErr)
+
+	data, httpErr = eng.GenerateOutput(ctx, root, map[string]any{"url": srv.URL + "/bearer"})
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(data))
+
+	_, httpErr = eng.GenerateOutput(ctx, root, map[string]any{"url": srv.URL + "/missing"})
+	require.EqualError(t, httpErr, "webhook request failed: not found")
+	require.Equal(t, http.StatusNotFound, httpErr.Code())
+}
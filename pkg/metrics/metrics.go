@@ -53,6 +53,15 @@ var (
 		[]string{"provider", "node"},
 	)
 
+	topologyWarningsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "topology_warning_total",
+			Help:      "Total number of warnings of the generated topologies.",
+			Subsystem: "topograph",
+		},
+		[]string{"provider", "kind"},
+	)
+
 	validationErrorsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:      "validation_error_total",
@@ -61,13 +70,84 @@ var (
 		},
 		[]string{"type"},
 	)
+
+	unchangedTopologyTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "unchanged_topology_total",
+			Help:      "Total number of topology updates skipped because the config was unchanged.",
+			Subsystem: "topograph",
+		},
+		[]string{"engine"},
+	)
+
+	topologyRollbackTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "topology_rollback_total",
+			Help:      "Total number of topology config rollbacks after failed activation.",
+			Subsystem: "topograph",
+		},
+		[]string{"engine"},
+	)
+
+	compositeConflictTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "conflict_total",
+			Help:      "Total number of node topology conflicts between the sub-providers.",
+			Subsystem: "topograph_composite",
+		},
+		[]string{"provider", "topology", "kind"},
+	)
+
+	fallbackProviderTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "provider_total",
+			Help:      "Total number of topologies generated by each fallback provider.",
+			Subsystem: "topograph_fallback",
+		},
+		[]string{"provider"},
+	)
+
+	fallbackFailureTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "failure_total",
+			Help:      "Total number of fallback provider failures.",
+			Subsystem: "topograph_fallback",
+		},
+		[]string{"provider", "status"},
+	)
+
+	cacheHitTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "hit_total",
+			Help:      "Total number of instances with cached topology.",
+			Subsystem: "topograph_cache",
+		},
+		[]string{"provider"},
+	)
+
+	cacheMissTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "miss_total",
+			Help:      "Total number of instances with uncached or expired topology.",
+			Subsystem: "topograph_cache",
+		},
+		[]string{"provider"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(httpRequestDuration)
 	prometheus.MustRegister(topologyRequestDuration)
 	prometheus.MustRegister(missingTopologyNodes)
+	prometheus.MustRegister(topologyWarningsTotal)
 	prometheus.MustRegister(validationErrorsTotal)
+	prometheus.MustRegister(unchangedTopologyTotal)
+	prometheus.MustRegister(topologyRollbackTotal)
+	prometheus.MustRegister(compositeConflictTotal)
+	prometheus.MustRegister(fallbackProviderTotal)
+	prometheus.MustRegister(fallbackFailureTotal)
+	prometheus.MustRegister(cacheHitTotal)
+	prometheus.MustRegister(cacheMissTotal)
 }
 
 func AddHttpRequest(method, path, proto, from string, code int, duration time.Duration) {
@@ -84,6 +164,36 @@ func SetMissingTopology(provider, nodename string) {
 	missingTopologyNodes.WithLabelValues(provider, nodename).Set(1.0)
 }
 
+func AddTopologyWarning(provider, kind string) {
+	topologyWarningsTotal.WithLabelValues(provider, kind).Inc()
+}
+
 func AddValidationError(errorType string) {
 	validationErrorsTotal.WithLabelValues(errorType).Inc()
 }
+
+func AddUnchangedTopology(engine string) {
+	unchangedTopologyTotal.WithLabelValues(engine).Inc()
+}
+
+func AddTopologyRollback(engine string) {
+	topologyRollbackTotal.WithLabelValues(engine).Inc()
+}
+
+func AddCompositeConflict(provider, topology, kind string) {
+	compositeConflictTotal.WithLabelValues(provider, topology, kind).Inc()
+}
+
+func AddFallbackProvider(provider string) {
+	fallbackProviderTotal.WithLabelValues(provider).Inc()
+}
+
+func AddFallbackFailure(provider string, code int) {
+	status := fmt.Sprintf("%d", code)
+	fallbackFailureTotal.WithLabelValues(provider, status).Inc()
+}
+
+func AddCacheLookups(provider string, hits, misses int) {
+	cacheHitTotal.WithLabelValues(provider).Add(float64(hits))
+	cacheMissTotal.WithLabelValues(provider).Add(float64(misses))
+}
@@ -47,6 +47,7 @@ type CapacityBlock struct {
 	Name   string   `yaml:"name"`
 	Type   string   `yaml:"type"`
 	NVLink string   `yaml:"nvlink,omitempty"`
+	Clique string   `yaml:"clique,omitempty"`
 	Nodes  []string `yaml:"nodes"`
 }
 
@@ -55,13 +56,14 @@ type Node struct {
 	Metadata      map[string]string
 	Type          string
 	NVLink        string
+	Clique        string
 	NetLayers     []string
 	CapacityBlock string
 }
 
 func (n *Node) String() string {
-	return fmt.Sprintf("Node: %s Metadata: %v Type: %s NVL: %s NetLayers: %v CBlock: %s",
-		n.Name, n.Metadata, n.Type, n.NVLink, n.NetLayers, n.CapacityBlock)
+	return fmt.Sprintf("Node: %s Metadata: %v Type: %s NVL: %s Clique: %s NetLayers: %v CBlock: %s",
+		n.Name, n.Metadata, n.Type, n.NVLink, n.Clique, n.NetLayers, n.CapacityBlock)
 }
 
 func NewModelFromFile(fname string) (*Model, error) {
@@ -135,6 +137,7 @@ func (m *Model) setNodeMap() error {
 				Metadata:      metadata,
 				Type:          cb.Type,
 				NVLink:        cb.NVLink,
+				Clique:        cb.Clique,
 				NetLayers:     netLayers,
 				CapacityBlock: cb.Name,
 			}
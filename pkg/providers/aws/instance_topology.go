@@ -27,21 +27,16 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
 var defaultPageSize int32 = 100
 
 func (p *baseProvider) generateInstanceTopology(ctx context.Context, pageSize *int, cis []topology.ComputeInstances) (*topology.ClusterTopology, *httperr.Error) {
-	topo := topology.NewClusterTopology()
-
-	for _, ci := range cis {
-		if err := p.generateRegionInstanceTopology(ctx, pageSize, &ci, topo); err != nil {
-			return nil, err
-		}
-	}
-
-	return topo, nil
+	return providers.GenerateInstanceTopology(ctx, cis, func(ctx context.Context, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error {
+		return p.generateRegionInstanceTopology(ctx, pageSize, ci, topo)
+	})
 }
 
 func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, pageSize *int, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error {
@@ -163,13 +163,9 @@ func LoaderSim(ctx context.Context, cfg providers.Config) (providers.Provider, *
 		return nil, httperr.NewError(http.StatusBadRequest, fmt.Sprintf("failed to load model file: %v", err))
 	}
 
-	sim := &simClient{
-		model:       model,
-		instanceIds: make([]string, 0, len(model.Nodes)),
-		apiErr:      p.APIError,
-	}
+	instanceIds := make([]string, 0, len(model.Nodes))
 	for _, node := range model.Nodes {
-		sim.instanceIds = append(sim.instanceIds, node.Name)
+		instanceIds = append(instanceIds, node.Name)
 	}
 
 	clientFactory := func(region string, pageSize *int) (*Client, error) {
@@ -177,6 +173,13 @@ func LoaderSim(ctx context.Context, cfg providers.Config) (providers.Provider, *
 			return nil, providers.ErrAPIError
 		}
 
+		// the regions are queried concurrently, so each client keeps its own pagination state
+		sim := &simClient{
+			model:       model,
+			instanceIds: instanceIds,
+			apiErr:      p.APIError,
+		}
+
 		return &Client{
 			ec2:      sim,
 			pageSize: setPageSize(pageSize),
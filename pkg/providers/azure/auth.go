@@ -0,0 +1,174 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/topograph/internal/httpreq"
+)
+
+const (
+	authTenantID     = "tenantId"
+	authClientID     = "clientId"
+	authClientSecret = "clientSecret"
+
+	loginURL              = "https://login.microsoftonline.com"
+	managementScope       = managementURL + "/.default"
+	managedIdentityAPIVer = "2018-02-01"
+
+	// tokenTimeDelay is the time before the token expiration when the token is renewed
+	tokenTimeDelay = 5 * time.Minute
+)
+
+// credential requests access tokens for the Azure Resource Manager
+type credential interface {
+	getToken(ctx context.Context) (*tokenResponse, error)
+}
+
+type tokenResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresIn   expiresIn `json:"expires_in"`
+}
+
+// expiresIn is the token lifetime in seconds;
+// Microsoft Entra ID returns a number, and the IMDS returns a string
+type expiresIn int64
+
+func (e *expiresIn) UnmarshalJSON(data []byte) error {
+	n, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expires_in %s", string(data))
+	}
+	*e = expiresIn(n)
+	return nil
+}
+
+// clientSecretCredential authenticates a service principal with a client secret
+type clientSecretCredential struct {
+	loginURL     string
+	tenantID     string
+	clientID     string
+	clientSecret string
+}
+
+func (c *clientSecretCredential) getToken(ctx context.Context) (*tokenResponse, error) {
+	payload := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+		"scope":         {managementScope},
+	}
+	headers := map[string]string{"Content-Type": "application/x-www-form-urlencoded"}
+	f := httpreq.GetRequestFunc(ctx, http.MethodPost, headers, nil, []byte(payload.Encode()), c.loginURL, c.tenantID, "oauth2/v2.0/token")
+
+	return requestToken(f)
+}
+
+// managedIdentityCredential authenticates the managed identity of the VM with the IMDS;
+// the client ID selects a user-assigned identity
+type managedIdentityCredential struct {
+	tokenURL string
+	clientID string
+}
+
+func (c *managedIdentityCredential) getToken(ctx context.Context) (*tokenResponse, error) {
+	headers := map[string]string{IMDSHeaderKey: IMDSHeaderVal}
+	query := map[string]string{
+		"api-version": managedIdentityAPIVer,
+		"resource":    managementURL + "/",
+	}
+	if len(c.clientID) != 0 {
+		query["client_id"] = c.clientID
+	}
+	f := httpreq.GetRequestFunc(ctx, http.MethodGet, headers, query, nil, c.tokenURL)
+
+	return requestToken(f)
+}
+
+func requestToken(f httpreq.RequestFunc) (*tokenResponse, error) {
+	body, httpErr := httpreq.DoRequestWithRetries(f, false)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	resp := &tokenResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %v", err)
+	}
+	if len(resp.AccessToken) == 0 {
+		return nil, fmt.Errorf("missing access token in token response")
+	}
+
+	return resp, nil
+}
+
+// getCredential returns the client secret credential if the client secret is set,
+// and the managed identity credential otherwise
+func getCredential(creds map[string]string) (credential, error) {
+	clientSecret, ok := creds[authClientSecret]
+	if !ok {
+		return &managedIdentityCredential{
+			tokenURL: IMDSTokenURL,
+			clientID: creds[authClientID],
+		}, nil
+	}
+
+	tenantID, ok := creds[authTenantID]
+	if !ok {
+		return nil, fmt.Errorf("missing tenant ID")
+	}
+	clientID, ok := creds[authClientID]
+	if !ok {
+		return nil, fmt.Errorf("missing client ID")
+	}
+
+	return &clientSecretCredential{
+		loginURL:     loginURL,
+		tenantID:     tenantID,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+	}, nil
+}
+
+// tokenCache shares the access token between the concurrent region queries
+// and renews it before the expiration
+type tokenCache struct {
+	cred    credential
+	mutex   sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newTokenCache(cred credential) *tokenCache {
+	return &tokenCache{cred: cred}
+}
+
+func (c *tokenCache) Token(ctx context.Context) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.token) != 0 && time.Now().Add(tokenTimeDelay).Before(c.expires) {
+		return c.token, nil
+	}
+
+	resp, err := c.cred.getToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.token = resp.AccessToken
+	c.expires = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+
+	return c.token, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	IMDSURL             = "http://169.254.169.254/metadata"
+	IMDSAPIVersion      = "2021-02-01"
+	IMDSComputeURL      = IMDSURL + "/instance/compute"
+	IMDSInstanceURL     = IMDSComputeURL + "/vmId?api-version=" + IMDSAPIVersion + "&format=text"
+	IMDSRegionURL       = IMDSComputeURL + "/location?api-version=" + IMDSAPIVersion + "&format=text"
+	IMDSSubscriptionURL = IMDSComputeURL + "/subscriptionId?api-version=" + IMDSAPIVersion + "&format=text"
+	IMDSTokenURL        = IMDSURL + "/identity/oauth2/token"
+	IMDSHeaderKey       = "Metadata"
+	IMDSHeaderVal       = "true"
+	IMDSHeader          = IMDSHeaderKey + ": " + IMDSHeaderVal
+)
+
+func instanceToNodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
+	stdout, err := exec.Pdsh(ctx, pdshCmd(IMDSInstanceURL), nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return providers.ParseInstanceOutput(stdout)
+}
+
+func getRegions(ctx context.Context, nodes []string) (map[string]string, error) {
+	stdout, err := exec.Pdsh(ctx, pdshCmd(IMDSRegionURL), nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return providers.ParsePdshOutput(stdout, true)
+}
+
+func pdshCmd(url string) string {
+	return fmt.Sprintf("echo $(curl -s -H %q %q)", IMDSHeader, url)
+}
+
+func getSubscriptionID(ctx context.Context) (string, error) {
+	header := map[string]string{IMDSHeaderKey: IMDSHeaderVal}
+	return providers.HttpReq(ctx, http.MethodGet, IMDSSubscriptionURL, header)
+}
+
+func GetNodeAnnotations(ctx context.Context, client *kubernetes.Clientset, config *rest.Config, hostname string) (map[string]string, error) {
+	header := map[string]string{IMDSHeaderKey: IMDSHeaderVal}
+	instance, err := providers.HttpReq(ctx, http.MethodGet, IMDSInstanceURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute vmId IMDS request: %v", err)
+	}
+
+	region, err := providers.HttpReq(ctx, http.MethodGet, IMDSRegionURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute location IMDS request: %v", err)
+	}
+
+	annotations := map[string]string{
+		topology.KeyNodeInstance: instance,
+		topology.KeyNodeRegion:   region,
+	}
+
+	// the NVLink domain is reported by nvidia-smi on the GPU nodes
+	if clusterID, err := infiniband.GetClusterID(ctx, client, config, hostname); err != nil {
+		klog.Warningf("No clusterID for node %s: %v", hostname, err)
+	} else if len(clusterID) != 0 {
+		annotations[topology.KeyNodeClusterID] = clusterID
+	}
+
+	return annotations, nil
+}
@@ -0,0 +1,18 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPdshCmd(t *testing.T) {
+	expected := fmt.Sprintf(`echo $(curl -s -H "Metadata: true" "%s")`, IMDSInstanceURL)
+	require.Equal(t, expected, pdshCmd(IMDSInstanceURL))
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func (p *baseProvider) generateInstanceTopology(ctx context.Context, pageSize *int, cis []topology.ComputeInstances) (*topology.ClusterTopology, *httperr.Error) {
+	client, err := p.clientFactory(pageSize)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to get client: %v", err))
+	}
+
+	// the tree topology is valid without the NVLink domains under the partial results policy,
+	// or if they are not available outside Kubernetes
+	domains, err := client.NVLinkDomains(ctx)
+	if err != nil && !errors.Is(err, infiniband.ErrNVLinkDomainsUnavailable) && !providers.Tolerable(ctx, http.StatusBadGateway) {
+		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to get NVLink domains: %v", err))
+	}
+
+	topo, httpErr := providers.GenerateInstanceTopology(ctx, cis, func(ctx context.Context, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error {
+		return p.generateRegionInstanceTopology(ctx, client, domains, topo, ci)
+	})
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	if err != nil {
+		topo.AddWarning(topology.Warning{
+			Kind:    topology.WarningNVLink,
+			Message: fmt.Sprintf("failed to get NVLink domains: %v", err),
+		})
+	}
+
+	return topo, nil
+}
+
+func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, client Client, domains map[string]string, topo *topology.ClusterTopology, ci *topology.ComputeInstances) *httperr.Error {
+	if len(ci.Region) == 0 {
+		return httperr.NewError(http.StatusBadRequest, "must specify region")
+	}
+	klog.InfoS("Getting instance topology", "region", ci.Region)
+
+	scaleSets, err := client.ScaleSets(ctx, ci.Region)
+	if err != nil {
+		return httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to list scale sets: %v", err))
+	}
+
+	for _, scaleSet := range scaleSets {
+		klog.V(4).InfoS("ListVirtualMachines", "scaleSet", scaleSet)
+		req := &VirtualMachineListRequest{ScaleSet: scaleSet}
+		for {
+			resp, err := client.VirtualMachines(ctx, req)
+			if err != nil {
+				return httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to list VMs of scale set %s: %v", scaleSet, err))
+			}
+
+			for i := range resp.Value {
+				vm := &resp.Value[i]
+				if node, ok := ci.Instances[vm.Properties.VMID]; ok {
+					if inst := convert(ci.Region, node, vm, domains, topo); inst != nil {
+						klog.Infof("Adding topology: %s", inst.String())
+						topo.Append(inst)
+					}
+				}
+			}
+
+			if len(resp.NextLink) == 0 {
+				break
+			}
+			req.NextLink = resp.NextLink
+		}
+	}
+
+	klog.V(4).Infof("Total processed nodes: %d", topo.Len())
+	return nil
+}
+
+// convert places the VM in the datacenter of its location and zone, under the placement group (InfiniBand fabric).
+// ARM exposes neither the leaf switches nor the NVLink domains; the NVLink domain is the cluster ID
+// reported by nvidia-smi on the node.
+func convert(region, node string, vm *VirtualMachine, domains map[string]string, topo *topology.ClusterTopology) *topology.InstanceTopology {
+	view := vm.Properties.InstanceView
+	if view == nil || len(view.PlacementGroupID) == 0 {
+		topo.AddWarning(topology.MissingTopologyWarning(region, node, "placementGroupId"))
+		missingTopologyInfo.WithLabelValues(vm.Properties.VMID).Inc()
+		return nil
+	}
+
+	inst := &topology.InstanceTopology{
+		InstanceID:   vm.Properties.VMID,
+		DatacenterID: region,
+		SpineID:      view.PlacementGroupID,
+	}
+	if len(vm.Zones) != 0 {
+		inst.DatacenterID = region + "-" + vm.Zones[0]
+	}
+
+	if domain, ok := domains[vm.Properties.VMID]; ok {
+		if infiniband.IsValidClusterID(domain) {
+			inst.AcceleratorID = domain
+		} else {
+			topo.AddWarning(topology.IncompleteNVLinkWarning(region, node, fmt.Sprintf("cluster ID %q", domain)))
+		}
+	}
+
+	return inst
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	missingTopologyInfo = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "missing_topology_info",
+			Subsystem: "topograph_azure",
+			Help:      "Number of times instance placement not found",
+		}, []string{"instance_name"},
+	)
+
+	requestLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name:       "request_latency",
+			Subsystem:  "topograph_azure",
+			Help:       "Latency of requests",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"method"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(missingTopologyInfo)
+	prometheus.MustRegister(requestLatency)
+}
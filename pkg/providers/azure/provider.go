@@ -0,0 +1,269 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/internal/httpreq"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "azure"
+
+	managementURL = "https://management.azure.com"
+	apiVersion    = "2024-07-01"
+)
+
+type Client interface {
+	// ScaleSets returns the names of the scale sets in the location
+	ScaleSets(ctx context.Context, location string) ([]string, error)
+	// VirtualMachines returns a page of the VMs of a scale set, including their instance view
+	VirtualMachines(ctx context.Context, req *VirtualMachineListRequest) (*VirtualMachineListResponse, error)
+	// NVLinkDomains maps the VM IDs to their NVLink domains
+	NVLinkDomains(ctx context.Context) (map[string]string, error)
+}
+
+type ClientFactory func(pageSize *int) (Client, error)
+
+type baseProvider struct {
+	clientFactory ClientFactory
+}
+
+type Params struct {
+	// SubscriptionID (optional) specifies the subscription of the scale sets;
+	// defaults to the subscription of the VM running topograph
+	SubscriptionID string `mapstructure:"subscriptionId"`
+	// ResourceGroup specifies the resource group of the scale sets
+	ResourceGroup string `mapstructure:"resourceGroup"`
+	// ScaleSets (optional) limits the query to the listed scale sets
+	ScaleSets []string `mapstructure:"scaleSets"`
+}
+
+type VirtualMachineListRequest struct {
+	ScaleSet string
+	NextLink string
+}
+
+type VirtualMachineListResponse struct {
+	Value    []VirtualMachine `json:"value"`
+	NextLink string           `json:"nextLink"`
+}
+
+// VirtualMachine represents a VM of a scale set in the uniform orchestration mode
+type VirtualMachine struct {
+	Name       string                   `json:"name"`
+	Zones      []string                 `json:"zones,omitempty"`
+	Properties VirtualMachineProperties `json:"properties"`
+}
+
+type VirtualMachineProperties struct {
+	VMID         string        `json:"vmId"`
+	InstanceView *InstanceView `json:"instanceView,omitempty"`
+}
+
+// InstanceView holds the placement of the VM: the VMs of a placement group share an InfiniBand fabric
+type InstanceView struct {
+	PlacementGroupID string `json:"placementGroupId,omitempty"`
+}
+
+type scaleSetListResponse struct {
+	Value []struct {
+		Name     string `json:"name"`
+		Location string `json:"location"`
+	} `json:"value"`
+	NextLink string `json:"nextLink"`
+}
+
+// azureClient is an Azure Resource Manager client
+type azureClient struct {
+	baseURL        string
+	tokens         *tokenCache
+	subscriptionID string
+	params         *Params
+
+	// ARM does not expose the NVLink domains of the VMs
+	infiniband.NVLinkAnnotations
+}
+
+func (c *azureClient) ScaleSets(ctx context.Context, location string) ([]string, error) {
+	var scaleSets []string
+	baseURL := c.baseURL
+	query := map[string]string{"api-version": apiVersion}
+	paths := []string{"subscriptions", c.subscriptionID, "resourceGroups", c.params.ResourceGroup,
+		"providers/Microsoft.Compute/virtualMachineScaleSets"}
+
+	for {
+		body, err := c.get(ctx, "ListScaleSets", baseURL, query, paths...)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &scaleSetListResponse{}
+		if err := json.Unmarshal(body, resp); err != nil {
+			return nil, fmt.Errorf("failed to parse scale set list: %v", err)
+		}
+
+		for _, ss := range resp.Value {
+			if !strings.EqualFold(ss.Location, location) {
+				continue
+			}
+			if len(c.params.ScaleSets) != 0 && !slices.Contains(c.params.ScaleSets, ss.Name) {
+				continue
+			}
+			scaleSets = append(scaleSets, ss.Name)
+		}
+
+		if len(resp.NextLink) == 0 {
+			return scaleSets, nil
+		}
+		// the next link is a complete URL
+		baseURL, query, paths = resp.NextLink, nil, nil
+	}
+}
+
+func (c *azureClient) VirtualMachines(ctx context.Context, req *VirtualMachineListRequest) (*VirtualMachineListResponse, error) {
+	var body []byte
+	var err error
+
+	if len(req.NextLink) != 0 {
+		body, err = c.get(ctx, "ListVirtualMachines", req.NextLink, nil)
+	} else {
+		query := map[string]string{"api-version": apiVersion, "$expand": "instanceView"}
+		body, err = c.get(ctx, "ListVirtualMachines", c.baseURL, query, "subscriptions", c.subscriptionID,
+			"resourceGroups", c.params.ResourceGroup, "providers/Microsoft.Compute/virtualMachineScaleSets",
+			req.ScaleSet, "virtualMachines")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &VirtualMachineListResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("failed to parse VM list: %v", err)
+	}
+
+	return resp, nil
+}
+
+func (c *azureClient) get(ctx context.Context, method, baseURL string, query map[string]string, paths ...string) ([]byte, error) {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %v", err)
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + token}
+	f := httpreq.GetRequestFunc(ctx, http.MethodGet, headers, query, nil, baseURL, paths...)
+
+	now := time.Now()
+	body, httpErr := httpreq.DoRequestWithRetries(f, false)
+	requestLatency.WithLabelValues(method).Observe(time.Since(now).Seconds())
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return body, nil
+}
+
+func NamedLoader() (string, providers.Loader) {
+	return NAME, Loader
+}
+
+func Loader(ctx context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+	p, err := getParameters(cfg.Params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	cred, err := getCredential(cfg.Creds)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+	tokens := newTokenCache(cred)
+
+	kubeClient, err := infiniband.GetInClusterClient()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to create kubernetes client: %v", err))
+	}
+
+	// ARM sets the page size of the VM list
+	clientFactory := func(_ *int) (Client, error) {
+		subscriptionID := p.SubscriptionID
+		if len(subscriptionID) == 0 {
+			var err error
+			if subscriptionID, err = getSubscriptionID(ctx); err != nil {
+				return nil, fmt.Errorf("failed to get subscription ID: %v", err)
+			}
+		}
+
+		return &azureClient{
+			baseURL:        managementURL,
+			tokens:         tokens,
+			subscriptionID: subscriptionID,
+			params:         p,
+			NVLinkAnnotations: infiniband.NVLinkAnnotations{
+				Client: kubeClient,
+			},
+		}, nil
+	}
+
+	return New(clientFactory), nil
+}
+
+func getParameters(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if len(p.ResourceGroup) == 0 {
+		return nil, fmt.Errorf("missing resource group")
+	}
+
+	return p, nil
+}
+
+func (p *baseProvider) GenerateTopologyConfig(ctx context.Context, pageSize *int, instances []topology.ComputeInstances) (*topology.Vertex, *httperr.Error) {
+	topo, err := p.generateInstanceTopology(ctx, pageSize, instances)
+	if err != nil {
+		return nil, err
+	}
+
+	return topo.ToThreeTierGraph(NAME, instances, false), nil
+}
+
+type Provider struct {
+	baseProvider
+}
+
+func New(clientFactory ClientFactory) *Provider {
+	return &Provider{
+		baseProvider: baseProvider{clientFactory: clientFactory},
+	}
+}
+
+// Engine support
+
+// Instances2NodeMap implements engines.instanceMapper
+func (p *Provider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
+	return instanceToNodeMap(ctx, nodes)
+}
+
+// GetInstancesRegions implements engines.instanceMapper
+func (p *Provider) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
+	return getRegions(ctx, nodes)
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/models"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME_SIM = "azure-sim"
+
+	errNone = iota
+	errClientFactory
+	errScaleSets
+	errVirtualMachines
+	errNVLinkDomains
+	errNVLinkUnavailable
+)
+
+// simClient represents the capacity blocks of the model as scale sets.
+// The VMs are placed in the placement group of their spine switch.
+type simClient struct {
+	model    *models.Model
+	pageSize int
+	apiErr   int
+}
+
+func (c *simClient) ScaleSets(_ context.Context, _ string) ([]string, error) {
+	if c.apiErr == errScaleSets {
+		return nil, providers.ErrAPIError
+	}
+
+	scaleSets := make([]string, 0, len(c.model.CapacityBlocks))
+	for _, cb := range c.model.CapacityBlocks {
+		scaleSets = append(scaleSets, cb.Name)
+	}
+
+	return scaleSets, nil
+}
+
+func (c *simClient) VirtualMachines(_ context.Context, req *VirtualMachineListRequest) (*VirtualMachineListResponse, error) {
+	if c.apiErr == errVirtualMachines {
+		return nil, providers.ErrAPIError
+	}
+
+	var nodes []string
+	for _, cb := range c.model.CapacityBlocks {
+		if cb.Name == req.ScaleSet {
+			nodes = cb.Nodes
+			break
+		}
+	}
+
+	resp := &VirtualMachineListResponse{Value: []VirtualMachine{}}
+
+	var indx int
+	from := getPage(req.NextLink)
+	for indx = from; indx < from+c.pageSize && indx < len(nodes); indx++ {
+		node := c.model.Nodes[nodes[indx]]
+		vm := VirtualMachine{
+			Name: node.Name,
+			Properties: VirtualMachineProperties{
+				VMID:         node.Name,
+				InstanceView: &InstanceView{},
+			},
+		}
+		if len(node.NetLayers) > 1 {
+			vm.Properties.InstanceView.PlacementGroupID = node.NetLayers[1]
+		}
+		resp.Value = append(resp.Value, vm)
+	}
+
+	if indx < len(nodes) {
+		resp.NextLink = strconv.Itoa(indx)
+	}
+
+	return resp, nil
+}
+
+// NVLinkDomains returns the cluster ID annotations of the nodes of the model, made of
+// the NVLink domain and the clique of their capacity block
+func (c *simClient) NVLinkDomains(_ context.Context) (map[string]string, error) {
+	switch c.apiErr {
+	case errNVLinkDomains:
+		return nil, providers.ErrAPIError
+	case errNVLinkUnavailable:
+		return nil, infiniband.ErrNVLinkDomainsUnavailable
+	}
+
+	domains := make(map[string]string)
+	for _, node := range c.model.Nodes {
+		switch {
+		case len(node.NVLink) == 0:
+			continue
+		case len(node.Clique) == 0:
+			domains[node.Name] = node.NVLink
+		default:
+			domains[node.Name] = node.NVLink + "." + node.Clique
+		}
+	}
+
+	return domains, nil
+}
+
+func getPage(page string) int {
+	val, _ := strconv.ParseInt(page, 10, 32)
+	return int(val)
+}
+
+func NamedLoaderSim() (string, providers.Loader) {
+	return NAME_SIM, LoaderSim
+}
+
+func LoaderSim(_ context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+	p, err := providers.GetSimulationParams(cfg.Params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	model, err := models.NewModelFromFile(p.ModelPath)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, fmt.Sprintf("failed to load model file: %v", err))
+	}
+
+	clientFactory := func(pageSize *int) (Client, error) {
+		if p.APIError == errClientFactory {
+			return nil, providers.ErrAPIError
+		}
+
+		limit := len(model.Nodes)
+		if pageSize != nil && *pageSize > 0 {
+			limit = *pageSize
+		}
+
+		return &simClient{
+			model:    model,
+			pageSize: limit,
+			apiErr:   p.APIError,
+		}, nil
+	}
+
+	return NewSim(clientFactory), nil
+}
+
+type simProvider struct {
+	baseProvider
+}
+
+func NewSim(clientFactory ClientFactory) *simProvider {
+	return &simProvider{
+		baseProvider: baseProvider{clientFactory: clientFactory},
+	}
+}
+
+// Engine support
+
+func (p *simProvider) GetComputeInstances(ctx context.Context) ([]topology.ComputeInstances, *httperr.Error) {
+	client, _ := p.clientFactory(nil)
+
+	return client.(*simClient).model.Instances, nil
+}
@@ -0,0 +1,319 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/agrea/ptr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines/slurm"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	ignoreErrMsg = "_IGNORE_"
+
+	nodeModel = `
+switches:
+- name: core
+  switches: [pg]
+- name: pg
+  switches: [tor]
+- name: tor
+  capacity_blocks: [cb]
+capacity_blocks:
+- name: cb
+  type: GB200
+  nvlink: nvl1
+  clique: "1"
+  nodes: [11]
+`
+
+	clusterModel = `
+switches:
+- name: core
+  switches: [pg]
+- name: pg
+  switches: [tor1,tor2]
+- name: tor1
+  capacity_blocks: [cb1]
+- name: tor2
+  capacity_blocks: [cb2]
+capacity_blocks:
+- name: cb1
+  type: GB200
+  nvlink: nvl1
+  clique: "1"
+  nodes: [11,12]
+- name: cb2
+  type: GB200
+  nvlink: nvl2
+  clique: "1"
+  nodes: [21,22]
+- name: cb3
+  type: GB200
+  nodes: [31]
+`
+
+	incompleteModel = `
+switches:
+- name: core
+  switches: [pg]
+- name: pg
+  switches: [tor1,tor2]
+- name: tor1
+  capacity_blocks: [cb1]
+- name: tor2
+  capacity_blocks: [cb2]
+capacity_blocks:
+- name: cb1
+  type: GB200
+  nvlink: nvl1
+  clique: "1"
+  nodes: [11,12]
+- name: cb2
+  type: GB200
+  nvlink: nvl2
+  nodes: [21,22]
+`
+)
+
+func TestProviderSim(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		model     string
+		pageSize  *int
+		instances []topology.ComputeInstances
+		params    map[string]any
+		apiErr    int
+		partial   bool
+		topology  string
+		err       string
+	}{
+		{
+			name:  "Case 1: bad model",
+			model: `bad: model: error:`,
+			err:   ignoreErrMsg,
+		},
+		{
+			name:  "Case 2: no ComputeInstances",
+			model: clusterModel,
+		},
+		{
+			name:  "Case 3.1: ClientFactory API error",
+			model: nodeModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11"},
+				},
+			},
+			apiErr: errClientFactory,
+			err:    "failed to get client: API error",
+		},
+		{
+			name:  "Case 3.2: ScaleSets API error",
+			model: nodeModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11"},
+				},
+			},
+			apiErr: errScaleSets,
+			err:    "failed to list scale sets: API error",
+		},
+		{
+			name:  "Case 3.3: VirtualMachines API error",
+			model: nodeModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11"},
+				},
+			},
+			apiErr: errVirtualMachines,
+			err:    "failed to list VMs of scale set cb: API error",
+		},
+		{
+			name:  "Case 4: missing region",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Instances: map[string]string{"11": "node11", "12": "nodeCPU"},
+				},
+			},
+			err: "must specify region",
+		},
+		{
+			name:  "Case 5: valid single node",
+			model: nodeModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11", "12": "nodeCPU"},
+				},
+			},
+			topology: `SwitchName=eastus Switches=pg
+SwitchName=no-topology Nodes=nodeCPU
+SwitchName=pg Nodes=node11
+`,
+		},
+		{
+			name:  "Case 6: valid cluster, no pagination",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21", "22": "node22", "31": "node31"},
+				},
+			},
+			topology: `# warning: node node31 missing placementGroupId
+SwitchName=eastus Switches=pg
+SwitchName=no-topology Nodes=node31
+SwitchName=pg Nodes=node[11-12,21-22]
+`,
+		},
+		{
+			name:     "Case 7: valid cluster, pagination",
+			model:    clusterModel,
+			pageSize: ptr.Int(1),
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21", "22": "node22"},
+				},
+			},
+			topology: `SwitchName=eastus Switches=pg
+SwitchName=pg Nodes=node[11-12,21-22]
+`,
+		},
+		{
+			name:   "Case 8: valid cluster in block format",
+			model:  clusterModel,
+			params: map[string]any{"plugin": "topology/block"},
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21", "22": "node22"},
+				},
+			},
+			topology: `# block002=nvl2.1
+BlockName=block002 Nodes=node[21-22]
+# block001=nvl1.1
+BlockName=block001 Nodes=node[11-12]
+BlockSizes=2,4
+`,
+		},
+		{
+			name:   "Case 9: incomplete NVLink domain",
+			model:  incompleteModel,
+			params: map[string]any{"plugin": "topology/block"},
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21"},
+				},
+			},
+			topology: `# warning: NVL data incomplete for node21: cluster ID "nvl2"
+# block001=nvl1.1
+BlockName=block001 Nodes=node[11-12]
+BlockSizes=2
+`,
+		},
+		{
+			name:  "Case 10: NVLink domains API error",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11"},
+				},
+			},
+			apiErr: errNVLinkDomains,
+			err:    "failed to get NVLink domains: API error",
+		},
+		{
+			name:  "Case 11: NVLink domains API error under the partial results policy",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11", "21": "node21"},
+				},
+			},
+			apiErr:  errNVLinkDomains,
+			partial: true,
+			topology: `# warning: failed to get NVLink domains: API error
+SwitchName=eastus Switches=pg
+SwitchName=pg Nodes=node[11,21]
+`,
+		},
+		{
+			name:  "Case 12: NVLink domains unavailable outside Kubernetes",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "eastus",
+					Instances: map[string]string{"11": "node11", "21": "node21"},
+				},
+			},
+			apiErr: errNVLinkUnavailable,
+			topology: `# warning: failed to get NVLink domains: not running in Kubernetes
+SwitchName=eastus Switches=pg
+SwitchName=pg Nodes=node[11,21]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := providers.WithPartialResults(ctx, tc.partial)
+			f, err := os.CreateTemp("", "test-*")
+			require.NoError(t, err)
+			defer func() { _ = os.Remove(f.Name()) }()
+			defer func() { _ = f.Close() }()
+			n, err := f.WriteString(tc.model)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.model), n)
+			err = f.Sync()
+			require.NoError(t, err)
+
+			cfg := providers.Config{
+				Params: map[string]any{
+					"model_path": f.Name(),
+					"api_error":  tc.apiErr,
+				},
+			}
+			provider, httpErr := LoaderSim(ctx, cfg)
+			if httpErr != nil {
+				if len(tc.err) == 0 {
+					require.Nil(t, httpErr)
+				} else if tc.err != ignoreErrMsg {
+					require.EqualError(t, httpErr, tc.err)
+				}
+				return
+			}
+
+			topo, httpErr := provider.GenerateTopologyConfig(ctx, tc.pageSize, tc.instances)
+			if len(tc.err) != 0 {
+				require.EqualError(t, httpErr, tc.err)
+			} else {
+				require.Nil(t, httpErr)
+				data, httpErr := slurm.GenerateOutput(ctx, topo, tc.params)
+				require.Nil(t, httpErr)
+				require.Equal(t, tc.topology, string(data))
+			}
+		})
+	}
+}
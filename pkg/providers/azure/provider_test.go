@@ -0,0 +1,206 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		ret    *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: missing resource group",
+			params: map[string]any{"subscriptionId": "sub"},
+			err:    "missing resource group",
+		},
+		{
+			name:   "Case 2: valid input",
+			params: map[string]any{"subscriptionId": "sub", "resourceGroup": "rg", "scaleSets": []string{"vmss1"}},
+			ret:    &Params{SubscriptionID: "sub", ResourceGroup: "rg", ScaleSets: []string{"vmss1"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.ret, p)
+			}
+		})
+	}
+}
+
+func TestGetCredential(t *testing.T) {
+	testCases := []struct {
+		name  string
+		creds map[string]string
+		cred  credential
+		err   string
+	}{
+		{
+			name: "Case 1: system-assigned managed identity",
+			cred: &managedIdentityCredential{tokenURL: IMDSTokenURL},
+		},
+		{
+			name:  "Case 2: user-assigned managed identity",
+			creds: map[string]string{"clientId": "id"},
+			cred:  &managedIdentityCredential{tokenURL: IMDSTokenURL, clientID: "id"},
+		},
+		{
+			name:  "Case 3: missing tenant ID",
+			creds: map[string]string{"clientId": "id", "clientSecret": "secret"},
+			err:   "missing tenant ID",
+		},
+		{
+			name:  "Case 4: missing client ID",
+			creds: map[string]string{"tenantId": "tenant", "clientSecret": "secret"},
+			err:   "missing client ID",
+		},
+		{
+			name:  "Case 5: client secret",
+			creds: map[string]string{"tenantId": "tenant", "clientId": "id", "clientSecret": "secret"},
+			cred:  &clientSecretCredential{loginURL: loginURL, tenantID: "tenant", clientID: "id", clientSecret: "secret"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cred, err := getCredential(tc.creds)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.cred, cred)
+			}
+		})
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	ctx := context.TODO()
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/tenant/oauth2/v2.0/token":
+			require.NoError(t, r.ParseForm())
+			require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			require.Equal(t, "id", r.PostForm.Get("client_id"))
+			require.Equal(t, "secret", r.PostForm.Get("client_secret"))
+			require.Equal(t, managementScope, r.PostForm.Get("scope"))
+			_, _ = fmt.Fprint(w, `{"access_token":"secret-token","expires_in":3599}`)
+		case "/identity":
+			require.Equal(t, IMDSHeaderVal, r.Header.Get(IMDSHeaderKey))
+			require.Equal(t, "id", r.URL.Query().Get("client_id"))
+			// the IMDS returns the token lifetime as a string; a short lifetime forces the renewal
+			_, _ = fmt.Fprint(w, `{"access_token":"identity-token","expires_in":"60"}`)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	// Case 1: the client secret token is cached
+	tokens := newTokenCache(&clientSecretCredential{loginURL: srv.URL, tenantID: "tenant", clientID: "id", clientSecret: "secret"})
+	for range 2 {
+		token, err := tokens.Token(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "secret-token", token)
+	}
+	require.Equal(t, 1, requests)
+
+	// Case 2: the managed identity token expiring shortly is renewed
+	requests = 0
+	tokens = newTokenCache(&managedIdentityCredential{tokenURL: srv.URL + "/identity", clientID: "id"})
+	for range 2 {
+		token, err := tokens.Token(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "identity-token", token)
+	}
+	require.Equal(t, 2, requests)
+
+	// Case 3: authentication error
+	tokens = newTokenCache(&clientSecretCredential{loginURL: srv.URL, tenantID: "other", clientID: "id", clientSecret: "secret"})
+	_, err := tokens.Token(ctx)
+	require.Error(t, err)
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.TODO()
+	base := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets"
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_, _ = fmt.Fprint(w, `{"access_token":"token","expires_in":"3600"}`)
+			return
+		}
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+
+		switch r.URL.Path {
+		case base:
+			if r.URL.Query().Get("page") == "" {
+				_, _ = fmt.Fprintf(w, `{"value":[{"name":"vmss1","location":"eastus"},{"name":"vmss2","location":"westus"}],"nextLink":"%s%s?api-version=%s&page=2"}`,
+					srv.URL, base, apiVersion)
+			} else {
+				_, _ = fmt.Fprint(w, `{"value":[{"name":"vmss3","location":"EastUS"},{"name":"vmss4","location":"eastus"}]}`)
+			}
+		case base + "/vmss1/virtualMachines":
+			require.Equal(t, "instanceView", r.URL.Query().Get("$expand"))
+			_, _ = fmt.Fprint(w, `{"value":[{"name":"vmss1_0","zones":["1"],"sku":{"name":"Standard_ND128isr_GB200_v6"},
+"properties":{"vmId":"id0","instanceView":{"placementGroupId":"pg","platformFaultDomain":0}}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := &azureClient{
+		baseURL:        srv.URL,
+		tokens:         newTokenCache(&managedIdentityCredential{tokenURL: srv.URL + "/token"}),
+		subscriptionID: "sub",
+		params:         &Params{ResourceGroup: "rg", ScaleSets: []string{"vmss1", "vmss3"}},
+	}
+
+	// the scale sets are filtered by location and name over the pages
+	scaleSets, err := client.ScaleSets(ctx, "eastus")
+	require.NoError(t, err)
+	require.Equal(t, []string{"vmss1", "vmss3"}, scaleSets)
+
+	resp, err := client.VirtualMachines(ctx, &VirtualMachineListRequest{ScaleSet: "vmss1"})
+	require.NoError(t, err)
+	require.Equal(t, &VirtualMachineListResponse{
+		Value: []VirtualMachine{
+			{
+				Name:  "vmss1_0",
+				Zones: []string{"1"},
+				Properties: VirtualMachineProperties{
+					VMID:         "id0",
+					InstanceView: &InstanceView{PlacementGroupID: "pg"},
+				},
+			},
+		},
+	}, resp)
+
+	_, err = client.VirtualMachines(ctx, &VirtualMachineListRequest{ScaleSet: "vmss5"})
+	require.Error(t, err)
+}
@@ -0,0 +1,328 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "cache"
+
+	DefaultTTL = 24 * time.Hour
+)
+
+// Provider caches the topology of the compute instances generated by the wrapped provider
+type Provider struct {
+	name     string
+	provider providers.Provider
+	params   *Params
+	// scope identifies the cache entries of the wrapped provider
+	scope scope
+}
+
+type Params struct {
+	// Provider specifies the wrapped provider
+	Provider SubProvider `mapstructure:"provider"`
+	// TTL (optional) specifies the time to live of the cached instance topology
+	TTL time.Duration `mapstructure:"ttl"`
+	// Path (optional) specifies the file persisting the cache
+	Path string `mapstructure:"path"`
+}
+
+type SubProvider struct {
+	// Name specifies the registered provider name
+	Name string `mapstructure:"name"`
+	// Params (optional) specifies the provider parameters
+	Params map[string]any `mapstructure:"params"`
+}
+
+// NamedLoader returns the cache provider loader, which loads the wrapped provider from the registry
+func NamedLoader(reg providers.Registry) providers.NamedLoader {
+	return func() (string, providers.Loader) {
+		return NAME, Loader(reg)
+	}
+}
+
+func Loader(reg providers.Registry) providers.Loader {
+	return func(ctx context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+		p, err := getParameters(cfg.Params)
+		if err != nil {
+			return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+		}
+
+		loader, httpErr := reg.Get(p.Provider.Name)
+		if httpErr != nil {
+			return nil, httpErr
+		}
+
+		// the credentials are passed to the wrapped provider
+		prv, httpErr := loader(ctx, providers.Config{Creds: cfg.Creds, Params: p.Provider.Params})
+		if httpErr != nil {
+			return nil, httpErr
+		}
+
+		if len(p.Path) != 0 {
+			if err = cache.load(p.Path); err != nil {
+				klog.Warning(err.Error())
+			}
+		}
+
+		return &Provider{
+			name:     p.Provider.Name,
+			provider: prv,
+			params:   p,
+			scope: scope{
+				provider: p.Provider.Name,
+				params:   providers.ParamsHash(p.Provider.Params),
+				creds:    credentialsHash(cfg.Creds),
+			},
+		}, nil
+	}
+}
+
+// credentialsHash returns the SHA-256 hash of the credentials, or an empty string without credentials.
+// The entries of different credentials, e.g. of different accounts, are kept apart without
+// persisting the credentials.
+func credentialsHash(creds map[string]string) string {
+	if len(creds) == 0 {
+		return ""
+	}
+	h := sha256.New()
+	for _, k := range slices.Sorted(maps.Keys(creds)) {
+		_, _ = fmt.Fprintf(h, "%s=%s\n", k, creds[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func getParameters(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	switch p.Provider.Name {
+	case "":
+		return nil, fmt.Errorf("missing provider name")
+	case NAME:
+		return nil, fmt.Errorf("nested %s provider is not supported", NAME)
+	}
+
+	switch {
+	case p.TTL < 0:
+		return nil, fmt.Errorf("invalid ttl %s", p.TTL)
+	case p.TTL == 0:
+		p.TTL = DefaultTTL
+	}
+
+	return p, nil
+}
+
+// Engine support
+
+// Instances2NodeMap implements engines.instanceMapper
+func (p *Provider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
+	m, err := p.instanceMapper()
+	if err != nil {
+		return nil, err
+	}
+	return m.Instances2NodeMap(ctx, nodes)
+}
+
+// GetInstancesRegions implements engines.instanceMapper
+func (p *Provider) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
+	m, err := p.instanceMapper()
+	if err != nil {
+		return nil, err
+	}
+	return m.GetInstancesRegions(ctx, nodes)
+}
+
+type instanceMapper interface {
+	Instances2NodeMap(context.Context, []string) (map[string]string, error)
+	GetInstancesRegions(context.Context, []string) (map[string]string, error)
+}
+
+func (p *Provider) instanceMapper() (instanceMapper, error) {
+	if m, ok := p.provider.(instanceMapper); ok {
+		return m, nil
+	}
+	return nil, fmt.Errorf("provider %s does not map nodes to instances", p.name)
+}
+
+// GenerateTopologyConfig fetches the topology of the uncached or expired instances from the wrapped provider,
+// and builds the topology of all instances from the cache
+func (p *Provider) GenerateTopologyConfig(ctx context.Context, pageSize *int, instances []topology.ComputeInstances) (*topology.Vertex, *httperr.Error) {
+	now := time.Now()
+	hits, missing := cache.lookup(p.scope, instances, now)
+
+	misses := 0
+	for _, ci := range missing {
+		misses += len(ci.Instances)
+	}
+	metrics.AddCacheLookups(p.name, len(hits), misses)
+	klog.Infof("Topology cache of provider %s: %d hits, %d misses", p.name, len(hits), misses)
+
+	// the metadata of the topology is the metadata of the last topology generated by the wrapped provider
+	metadata := cache.getMetadata(p.scope)
+	var warnings []topology.Warning
+	hasTree := slices.ContainsFunc(slices.Collect(maps.Values(hits)), func(e *Entry) bool { return len(e.Switches) != 0 })
+
+	if len(missing) != 0 {
+		root, err := p.provider.GenerateTopologyConfig(ctx, pageSize, missing)
+		if err != nil {
+			return nil, err
+		}
+		metadata = root.Metadata
+		cache.setMetadata(p.scope, metadata)
+		warnings = root.Warnings
+		_, ok := root.Vertices[topology.TopologyTree]
+		hasTree = hasTree || ok
+
+		entries := getEntries(p.scope, root, missing, now.Add(p.params.TTL))
+		for _, e := range entries {
+			hits[e.Instance] = e
+		}
+		cache.add(entries)
+	}
+
+	if len(p.params.Path) != 0 {
+		if err := cache.save(p.params.Path); err != nil {
+			klog.Warningf("Failed to save topology cache: %v", err)
+		}
+	}
+
+	root := p.toGraph(hits, instances, metadata, hasTree)
+	root.Warnings = warnings
+
+	return root, nil
+}
+
+// getEntries returns the cache entries of the requested instances with topology
+func getEntries(sc scope, root *topology.Vertex, instances []topology.ComputeInstances, expires time.Time) []*Entry {
+	switches := make(map[string][]Switch)
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok {
+		var walk func(v *topology.Vertex, path []Switch)
+		walk = func(v *topology.Vertex, path []Switch) {
+			path = append(path, Switch{ID: v.ID, Name: v.Name})
+			for _, w := range v.Vertices {
+				if len(w.Vertices) == 0 {
+					switches[w.ID] = slices.Clone(path)
+				} else {
+					walk(w, path)
+				}
+			}
+		}
+		for _, v := range tree.Vertices {
+			if v.ID != topology.NoTopology {
+				walk(v, nil)
+			}
+		}
+	}
+
+	domains := make(map[string]string)
+	if blocks, ok := root.Vertices[topology.TopologyBlock]; ok {
+		for _, block := range blocks.Vertices {
+			domain := block.Name
+			if len(domain) == 0 {
+				domain = block.ID
+			}
+			for _, w := range block.Vertices {
+				domains[w.ID] = domain
+			}
+		}
+	}
+
+	entries := []*Entry{}
+	for _, ci := range instances {
+		for instance := range ci.Instances {
+			e := &Entry{
+				Provider: sc.provider,
+				Params:   sc.params,
+				Creds:    sc.creds,
+				Region:   ci.Region,
+				Instance: instance,
+				Switches: switches[instance],
+				Domain:   domains[instance],
+				Expires:  expires,
+			}
+			// instances without topology are fetched again in the next request
+			if len(e.Switches) != 0 || len(e.Domain) != 0 {
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	return entries
+}
+
+// toGraph builds the topology of the requested instances from the cache entries
+func (p *Provider) toGraph(entries map[string]*Entry, instances []topology.ComputeInstances, metadata map[string]string, hasTree bool) *topology.Vertex {
+	root := &topology.Vertex{
+		Vertices: make(map[string]*topology.Vertex),
+		Metadata: maps.Clone(metadata),
+	}
+	treeRoot := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+	switches := make(map[string]*topology.Vertex)
+	domainMap := topology.NewDomainMap()
+
+	for _, ci := range instances {
+		for instance, node := range ci.Instances {
+			e, ok := entries[instance]
+			if !ok || len(e.Switches) == 0 {
+				if hasTree {
+					sw, ok := treeRoot.Vertices[topology.NoTopology]
+					if !ok {
+						sw = &topology.Vertex{ID: topology.NoTopology, Vertices: make(map[string]*topology.Vertex)}
+						treeRoot.Vertices[topology.NoTopology] = sw
+					}
+					sw.Vertices[instance] = &topology.Vertex{ID: instance, Name: node}
+					metrics.SetMissingTopology(p.name, node)
+				}
+			} else {
+				parent := treeRoot
+				for _, s := range e.Switches {
+					sw, ok := switches[s.ID]
+					if !ok {
+						sw = &topology.Vertex{ID: s.ID, Name: s.Name, Vertices: make(map[string]*topology.Vertex)}
+						switches[s.ID] = sw
+					}
+					parent.Vertices[sw.ID] = sw
+					parent = sw
+				}
+				parent.Vertices[instance] = &topology.Vertex{ID: instance, Name: node}
+			}
+
+			if ok && len(e.Domain) != 0 {
+				domainMap.AddHost(e.Domain, instance, node)
+			}
+		}
+	}
+
+	if hasTree {
+		root.Vertices[topology.TopologyTree] = treeRoot
+	}
+	if len(domainMap) != 0 {
+		root.Vertices[topology.TopologyBlock] = domainMap.ToBlocks()
+	}
+
+	return root
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cache
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/component"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// testProvider places the instances i1, i2 under leaf1 and i3 under leaf2 of the spine switch,
+// with i1 and i2 in the NVLink domain nvl1; other instances have no topology
+type testProvider struct {
+	// requests lists the instances of each request
+	requests [][]string
+}
+
+var testLeaves = map[string]string{"i1": "leaf1", "i2": "leaf1", "i3": "leaf2"}
+
+func (p *testProvider) GenerateTopologyConfig(_ context.Context, _ *int, cis []topology.ComputeInstances) (*topology.Vertex, *httperr.Error) {
+	var requested []string
+	spine := &topology.Vertex{ID: "spine", Vertices: make(map[string]*topology.Vertex)}
+	domainMap := topology.NewDomainMap()
+
+	for _, ci := range cis {
+		for instance, node := range ci.Instances {
+			requested = append(requested, instance)
+			leafID, ok := testLeaves[instance]
+			if !ok {
+				continue
+			}
+			leaf, ok := spine.Vertices[leafID]
+			if !ok {
+				leaf = &topology.Vertex{ID: leafID, Vertices: make(map[string]*topology.Vertex)}
+				spine.Vertices[leafID] = leaf
+			}
+			leaf.Vertices[instance] = &topology.Vertex{ID: instance, Name: node}
+			if leafID == "leaf1" {
+				domainMap.AddHost("nvl1", instance, node)
+			}
+		}
+	}
+	slices.Sort(requested)
+	p.requests = append(p.requests, requested)
+
+	root := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {Vertices: map[string]*topology.Vertex{"spine": spine}},
+		},
+		Metadata: map[string]string{topology.KeyGeneratedAt: "2026-01-01T00:00:00Z"},
+	}
+	if len(domainMap) != 0 {
+		root.Vertices[topology.TopologyBlock] = domainMap.ToBlocks()
+	}
+	return root, nil
+}
+
+func (p *testProvider) lastRequest() []string {
+	if len(p.requests) == 0 {
+		return nil
+	}
+	return p.requests[len(p.requests)-1]
+}
+
+func testLoader(t *testing.T, prv *testProvider, params map[string]any, creds ...map[string]string) providers.Provider {
+	reg := providers.NewRegistry(component.Named("csp", func(_ context.Context, _ providers.Config) (providers.Provider, *httperr.Error) {
+		return prv, nil
+	}))
+	cfg := providers.Config{Params: params}
+	if len(creds) != 0 {
+		cfg.Creds = creds[0]
+	}
+	p, err := Loader(reg)(context.TODO(), cfg)
+	require.Nil(t, err)
+	return p
+}
+
+func getLeaves(root *topology.Vertex) map[string]string {
+	leaves := make(map[string]string)
+	var walk func(v *topology.Vertex)
+	walk = func(v *topology.Vertex) {
+		for _, w := range v.Vertices {
+			if len(w.Vertices) == 0 {
+				leaves[w.Name] = v.ID
+			} else {
+				walk(w)
+			}
+		}
+	}
+	walk(root.Vertices[topology.TopologyTree])
+	return leaves
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		ret    *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: missing provider",
+			params: map[string]any{},
+			err:    "missing provider name",
+		},
+		{
+			name:   "Case 2: nested cache provider",
+			params: map[string]any{"provider": map[string]any{"name": "cache"}},
+			err:    "nested cache provider is not supported",
+		},
+		{
+			name:   "Case 3: invalid ttl",
+			params: map[string]any{"provider": map[string]any{"name": "aws"}, "ttl": "-1h"},
+			err:    "invalid ttl -1h0m0s",
+		},
+		{
+			name:   "Case 4: default ttl",
+			params: map[string]any{"provider": map[string]any{"name": "aws", "params": map[string]any{"a": "b"}}},
+			ret: &Params{
+				Provider: SubProvider{Name: "aws", Params: map[string]any{"a": "b"}},
+				TTL:      DefaultTTL,
+			},
+		},
+		{
+			name:   "Case 5: valid input",
+			params: map[string]any{"provider": map[string]any{"name": "aws"}, "ttl": "1h", "path": "/tmp/cache.json"},
+			ret: &Params{
+				Provider: SubProvider{Name: "aws"},
+				TTL:      time.Hour,
+				Path:     "/tmp/cache.json",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.ret, p)
+			}
+		})
+	}
+}
+
+func TestGenerateTopologyConfig(t *testing.T) {
+	cache = newStore()
+	ctx := context.TODO()
+	csp := &testProvider{}
+	prv := testLoader(t, csp, map[string]any{"provider": map[string]any{"name": "csp"}})
+
+	instances := []topology.ComputeInstances{
+		{Region: "r1", Instances: map[string]string{"i1": "n1", "i2": "n2"}},
+		{Region: "r2", Instances: map[string]string{"i3": "n3", "i4": "n4"}},
+	}
+
+	// Case 1: all instances are fetched
+	root, err := prv.GenerateTopologyConfig(ctx, nil, instances)
+	require.Nil(t, err)
+	require.Equal(t, []string{"i1", "i2", "i3", "i4"}, csp.lastRequest())
+	require.Equal(t, map[string]string{"n1": "leaf1", "n2": "leaf1", "n3": "leaf2", "n4": topology.NoTopology}, getLeaves(root))
+	require.Equal(t, []string{"n1", "n2"}, slices.Sorted(maps.Keys(root.Vertices[topology.TopologyBlock].Vertices["nvl1"].Vertices)))
+	require.Equal(t, "2026-01-01T00:00:00Z", root.Metadata[topology.KeyGeneratedAt])
+	require.Len(t, cache.entries, 3)
+
+	// Case 2: only the instance without topology is fetched
+	expected := root
+	root, err = prv.GenerateTopologyConfig(ctx, nil, instances)
+	require.Nil(t, err)
+	require.Equal(t, []string{"i4"}, csp.lastRequest())
+	require.Equal(t, getLeaves(expected), getLeaves(root))
+	require.Equal(t, expected.Vertices[topology.TopologyBlock], root.Vertices[topology.TopologyBlock])
+
+	// Case 3: a request for a subset of the instances is served from the cache, and keeps the other entries
+	subset := []topology.ComputeInstances{
+		{Region: "r1", Instances: map[string]string{"i1": "n1"}},
+		{Region: "r2", Instances: map[string]string{"i3": "n3"}},
+	}
+	requests := len(csp.requests)
+	root, err = prv.GenerateTopologyConfig(ctx, nil, subset)
+	require.Nil(t, err)
+	require.Len(t, csp.requests, requests)
+	require.Equal(t, "2026-01-01T00:00:00Z", root.Metadata[topology.KeyGeneratedAt])
+	require.Equal(t, map[string]string{"n1": "leaf1", "n3": "leaf2"}, getLeaves(root))
+	require.Len(t, cache.entries, 3)
+
+	// Case 4: the expired instance is fetched
+	sc := scope{provider: "csp", params: providers.ParamsHash(nil)}
+	cache.entries[key{scope: sc, region: "r2", instance: "i3"}].Expires = time.Now().Add(-time.Second)
+	_, err = prv.GenerateTopologyConfig(ctx, nil, subset)
+	require.Nil(t, err)
+	require.Equal(t, []string{"i3"}, csp.lastRequest())
+
+	// Case 5: the entries are not shared with other parameters of the wrapped provider
+	other := testLoader(t, csp, map[string]any{"provider": map[string]any{"name": "csp", "params": map[string]any{"model_path": "other.yaml"}}})
+	_, err = other.GenerateTopologyConfig(ctx, nil, subset)
+	require.Nil(t, err)
+	require.Equal(t, []string{"i1", "i3"}, csp.lastRequest())
+	require.Len(t, cache.entries, 5)
+
+	// Case 6: the entries are not shared with other credentials of the wrapped provider
+	other = testLoader(t, csp, map[string]any{"provider": map[string]any{"name": "csp"}}, map[string]string{"token": "other"})
+	_, err = other.GenerateTopologyConfig(ctx, nil, subset)
+	require.Nil(t, err)
+	require.Equal(t, []string{"i1", "i3"}, csp.lastRequest())
+	require.Len(t, cache.entries, 7)
+}
+
+func TestPersistence(t *testing.T) {
+	cache = newStore()
+	ctx := context.TODO()
+	csp := &testProvider{}
+	params := map[string]any{
+		"provider": map[string]any{"name": "csp"},
+		"path":     filepath.Join(t.TempDir(), "cache.json"),
+	}
+	instances := []topology.ComputeInstances{
+		{Region: "r1", Instances: map[string]string{"i1": "n1", "i2": "n2"}},
+	}
+
+	_, err := testLoader(t, csp, params).GenerateTopologyConfig(ctx, nil, instances)
+	require.Nil(t, err)
+	require.Len(t, csp.requests, 1)
+
+	// the cache is read from the file after a restart
+	cache = newStore()
+	root, err := testLoader(t, csp, params).GenerateTopologyConfig(ctx, nil, instances)
+	require.Nil(t, err)
+	require.Len(t, csp.requests, 1)
+	require.Equal(t, map[string]string{"n1": "leaf1", "n2": "leaf1"}, getLeaves(root))
+	require.Contains(t, root.Vertices, topology.TopologyBlock)
+	require.Equal(t, map[string]string{topology.KeyGeneratedAt: "2026-01-01T00:00:00Z"}, root.Metadata)
+
+	// the changes are kept for the next save if the file cannot be written
+	cache.add([]*Entry{{Provider: "csp", Instance: "i3"}})
+	require.Error(t, cache.save(filepath.Join(t.TempDir(), "missing", "cache.json")))
+	require.True(t, cache.dirty)
+	require.NoError(t, cache.save(params["path"].(string)))
+	require.False(t, cache.dirty)
+}
+
+func TestLoaderErrors(t *testing.T) {
+	reg := providers.NewRegistry()
+	_, err := Loader(reg)(context.TODO(), providers.Config{Params: map[string]any{"provider": map[string]any{"name": "aws"}}})
+	require.Equal(t, httperr.NewError(http.StatusBadRequest, `unsupported provider "aws"`), err)
+
+	_, err = Loader(reg)(context.TODO(), providers.Config{})
+	require.Equal(t, httperr.NewError(http.StatusBadRequest, "missing provider name"), err)
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// scope identifies the wrapped provider by its name and the hashes of its parameters and credentials
+type scope struct {
+	provider string
+	params   string
+	creds    string
+}
+
+type key struct {
+	scope
+	region   string
+	instance string
+}
+
+// Entry is the cached topology of a compute instance
+type Entry struct {
+	Provider string `json:"provider"`
+	// Params is the hash of the provider parameters
+	Params string `json:"params,omitempty"`
+	// Creds is the hash of the provider credentials
+	Creds    string `json:"creds,omitempty"`
+	Region   string `json:"region,omitempty"`
+	Instance string `json:"instance"`
+	// Switches lists the switches of the instance from the top down
+	Switches []Switch `json:"switches,omitempty"`
+	// Domain is the NVLink domain of the instance
+	Domain  string    `json:"domain,omitempty"`
+	Expires time.Time `json:"expires"`
+}
+
+type Switch struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+func (e *Entry) key() key {
+	return key{scope: scope{provider: e.Provider, params: e.Params, creds: e.Creds}, region: e.Region, instance: e.Instance}
+}
+
+// Metadata is the root metadata of the last topology generated by the provider
+type Metadata struct {
+	Provider string            `json:"provider"`
+	Params   string            `json:"params,omitempty"`
+	Creds    string            `json:"creds,omitempty"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// cacheFile is the content of the persistence file
+type cacheFile struct {
+	Entries  []*Entry    `json:"entries"`
+	Metadata []*Metadata `json:"metadata,omitempty"`
+}
+
+// store holds the cached entries of all providers
+type store struct {
+	sync.Mutex
+	entries  map[key]*Entry
+	metadata map[scope]map[string]string
+	// loaded lists the persistence files read into the store
+	loaded map[string]bool
+	// dirty is set if the entries changed since the last save
+	dirty bool
+	// writer serializes the saves, so that the snapshots are written in order
+	writer sync.Mutex
+}
+
+var cache = newStore()
+
+func newStore() *store {
+	return &store{
+		entries:  make(map[key]*Entry),
+		metadata: make(map[scope]map[string]string),
+		loaded:   make(map[string]bool),
+	}
+}
+
+// lookup returns the valid entries of the instances, and the instances to fetch from the provider.
+// Expired entries are removed. The entries of the instances missing in the request are kept,
+// since a request can cover a subset of the nodes, e.g. a partition.
+func (s *store) lookup(sc scope, instances []topology.ComputeInstances, now time.Time) (map[string]*Entry, []topology.ComputeInstances) {
+	s.Lock()
+	defer s.Unlock()
+
+	for k, e := range s.entries {
+		if !e.Expires.After(now) {
+			klog.V(4).Infof("Cached topology of instance %s expired", k.instance)
+			delete(s.entries, k)
+			s.dirty = true
+		}
+	}
+
+	hits := make(map[string]*Entry)
+	missing := []topology.ComputeInstances{}
+	for _, ci := range instances {
+		fetch := topology.ComputeInstances{Region: ci.Region, Instances: make(map[string]string)}
+		for instance, node := range ci.Instances {
+			if e, ok := s.entries[key{scope: sc, region: ci.Region, instance: instance}]; ok {
+				hits[instance] = e
+			} else {
+				fetch.Instances[instance] = node
+			}
+		}
+		if len(fetch.Instances) != 0 {
+			missing = append(missing, fetch)
+		}
+	}
+
+	return hits, missing
+}
+
+// add stores the entries
+func (s *store) add(entries []*Entry) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, e := range entries {
+		s.entries[e.key()] = e
+		s.dirty = true
+	}
+}
+
+// setMetadata stores the root metadata of the topology generated by the provider
+func (s *store) setMetadata(sc scope, metadata map[string]string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if len(metadata) == 0 {
+		delete(s.metadata, sc)
+	} else {
+		s.metadata[sc] = maps.Clone(metadata)
+	}
+	s.dirty = true
+}
+
+// getMetadata returns the root metadata of the last topology generated by the provider
+func (s *store) getMetadata(sc scope) map[string]string {
+	s.Lock()
+	defer s.Unlock()
+
+	return maps.Clone(s.metadata[sc])
+}
+
+// load reads the persistence file into the store once
+func (s *store) load(path string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.loaded[path] {
+		return nil
+	}
+	s.loaded[path] = true
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read topology cache %s: %v", path, err)
+	}
+
+	var file cacheFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse topology cache %s: %v", path, err)
+	}
+	for _, e := range file.Entries {
+		if _, ok := s.entries[e.key()]; !ok {
+			s.entries[e.key()] = e
+		}
+	}
+	for _, m := range file.Metadata {
+		sc := scope{provider: m.Provider, params: m.Params, creds: m.Creds}
+		if _, ok := s.metadata[sc]; !ok {
+			s.metadata[sc] = m.Metadata
+		}
+	}
+	klog.Infof("Loaded %d cached instances from %s", len(file.Entries), path)
+
+	return nil
+}
+
+// save writes the entries into the persistence file if they changed
+func (s *store) save(path string) error {
+	s.writer.Lock()
+	defer s.writer.Unlock()
+
+	s.Lock()
+	if !s.dirty {
+		s.Unlock()
+		return nil
+	}
+	s.dirty = false
+	file := cacheFile{Entries: make([]*Entry, 0, len(s.entries))}
+	for _, k := range slices.SortedFunc(maps.Keys(s.entries), compareKeys) {
+		file.Entries = append(file.Entries, s.entries[k])
+	}
+	for _, sc := range slices.SortedFunc(maps.Keys(s.metadata), compareScopes) {
+		file.Metadata = append(file.Metadata, &Metadata{Provider: sc.provider, Params: sc.params, Creds: sc.creds, Metadata: s.metadata[sc]})
+	}
+	s.Unlock()
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err == nil {
+		err = files.CreateAtomic(path, append(data, '\n'))
+	} else {
+		err = fmt.Errorf("failed to marshal topology cache: %v", err)
+	}
+
+	// the changes are saved again on the next request
+	if err != nil {
+		s.Lock()
+		s.dirty = true
+		s.Unlock()
+	}
+
+	return err
+}
+
+func compareScopes(a, b scope) int {
+	if c := strings.Compare(a.provider, b.provider); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.params, b.params); c != 0 {
+		return c
+	}
+	return strings.Compare(a.creds, b.creds)
+}
+
+func compareKeys(a, b key) int {
+	if c := compareScopes(a.scope, b.scope); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.region, b.region); c != 0 {
+		return c
+	}
+	return strings.Compare(a.instance, b.instance)
+}
@@ -0,0 +1,334 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package composite
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const ConflictMismatch = "mismatch"
+
+// conflict describes a node grouped with different nodes by the sub-providers
+type conflict struct {
+	// topology is either RoleTree or RoleBlock
+	topology string
+	// provider is the sub-provider that disagrees with the selected one
+	provider string
+	// selected is the sub-provider whose topology is used
+	selected string
+	node     string
+	detail   string
+}
+
+func (c *conflict) String() string {
+	return fmt.Sprintf("%s topology of node %s differs in provider %s (%s); using provider %s",
+		c.topology, c.node, c.provider, c.detail, c.selected)
+}
+
+// warning reports the conflict in the topology
+func (c *conflict) warning() topology.Warning {
+	return topology.Warning{Kind: topology.WarningConflict, Node: c.node, Message: c.String()}
+}
+
+// treeLeaf is a compute node in the tree topology with its switches from the top down
+type treeLeaf struct {
+	name string
+	path []*topology.Vertex
+}
+
+// leafSwitch returns the ID of the switch connecting the node
+func (l *treeLeaf) leafSwitch() string {
+	return l.path[len(l.path)-1].ID
+}
+
+// blockLeaf is a compute node in the block topology
+type blockLeaf struct {
+	name   string
+	domain string
+}
+
+// grouping maps the instances of a source to their leaf switch or NVLink domain.
+// The group IDs are local to the source, so the sources are compared by the group members.
+type grouping struct {
+	groups  map[string]string
+	members map[string][]string
+}
+
+func newGrouping[T any](leaves map[string]T, group func(T) string) *grouping {
+	g := &grouping{groups: make(map[string]string), members: make(map[string][]string)}
+	for instance, leaf := range leaves {
+		id := group(leaf)
+		g.groups[instance] = id
+		g.members[id] = append(g.members[id], instance)
+	}
+	return g
+}
+
+// peers returns the compacted names of the nodes sharing the group of the instance,
+// limited to the nodes present in the other grouping
+func (g *grouping) peers(instance string, other *grouping, nodes map[string]string) []string {
+	names := []string{}
+	for _, peer := range g.members[g.groups[instance]] {
+		if _, ok := other.groups[peer]; ok && peer != instance {
+			names = append(names, nodes[peer])
+		}
+	}
+	return cluset.Compact(names)
+}
+
+// mismatch returns the description of the difference if the instance shares its group
+// with different nodes in the other grouping
+func (g *grouping) mismatch(instance string, other *grouping, nodes map[string]string, group string) (string, bool) {
+	selected, peers := g.peers(instance, other, nodes), other.peers(instance, g, nodes)
+	if slices.Equal(selected, peers) {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s shared with %s instead of %s", group, other.groups[instance], listNodes(peers), listNodes(selected)), true
+}
+
+func listNodes(names []string) string {
+	if len(names) == 0 {
+		return "no nodes"
+	}
+	return strings.Join(names, ",")
+}
+
+// merge combines the tree and block topologies of the sources, listed in the order of precedence.
+// Each node takes its topology from the highest-precedence source that has it.
+func merge(sources []*source, roots []*topology.Vertex, instances []topology.ComputeInstances) (*topology.Vertex, []*conflict) {
+	// node names by instance ID; the request names take precedence over the source names
+	nodes := make(map[string]string)
+	trees := make([]map[string]*treeLeaf, len(sources))
+	blocks := make([]map[string]*blockLeaf, len(sources))
+
+	root := &topology.Vertex{
+		Vertices: make(map[string]*topology.Vertex),
+		Metadata: make(map[string]string),
+	}
+
+	for i := len(sources) - 1; i >= 0; i-- {
+		src, r := sources[i], roots[i]
+		if r == nil {
+			continue
+		}
+		maps.Copy(root.Metadata, r.Metadata)
+
+		if src.role != RoleBlock {
+			trees[i] = getTreeLeaves(r.Vertices[topology.TopologyTree], nodes)
+		}
+		if src.role != RoleTree {
+			blocks[i] = getBlockLeaves(r.Vertices[topology.TopologyBlock], nodes)
+		}
+	}
+
+	for _, ci := range instances {
+		maps.Copy(nodes, ci.Instances)
+	}
+
+	var conflicts []*conflict
+	if tree, c := mergeTrees(sources, trees, nodes); tree != nil {
+		root.Vertices[topology.TopologyTree] = tree
+		conflicts = append(conflicts, c...)
+	}
+	if blockRoot, c := mergeBlocks(sources, blocks, nodes); blockRoot != nil {
+		root.Vertices[topology.TopologyBlock] = blockRoot
+		conflicts = append(conflicts, c...)
+	}
+
+	if len(root.Metadata) == 0 {
+		root.Metadata = nil
+	}
+
+	return root, conflicts
+}
+
+// getTreeLeaves returns the nodes with tree topology by instance ID, and adds all node names to the nodes map
+func getTreeLeaves(tree *topology.Vertex, nodes map[string]string) map[string]*treeLeaf {
+	leaves := make(map[string]*treeLeaf)
+	if tree == nil {
+		return leaves
+	}
+
+	var walk func(v *topology.Vertex, path []*topology.Vertex)
+	walk = func(v *topology.Vertex, path []*topology.Vertex) {
+		path = append(path, v)
+		for _, w := range v.Vertices {
+			if len(w.Vertices) == 0 {
+				leaves[w.ID] = &treeLeaf{name: w.Name, path: slices.Clone(path)}
+				nodes[w.ID] = w.Name
+			} else {
+				walk(w, path)
+			}
+		}
+	}
+
+	for _, v := range tree.Vertices {
+		if v.ID == topology.NoTopology {
+			for _, w := range v.Vertices {
+				nodes[w.ID] = w.Name
+			}
+			continue
+		}
+		walk(v, nil)
+	}
+
+	return leaves
+}
+
+// getBlockLeaves returns the nodes in blocks by instance ID, and adds all node names to the nodes map
+func getBlockLeaves(blockRoot *topology.Vertex, nodes map[string]string) map[string]*blockLeaf {
+	leaves := make(map[string]*blockLeaf)
+	if blockRoot == nil {
+		return leaves
+	}
+
+	for _, block := range blockRoot.Vertices {
+		domain := block.Name
+		if len(domain) == 0 {
+			domain = block.ID
+		}
+		for _, w := range block.Vertices {
+			leaves[w.ID] = &blockLeaf{name: w.Name, domain: domain}
+			nodes[w.ID] = w.Name
+		}
+	}
+
+	return leaves
+}
+
+// mergeTrees builds the tree topology from the sources supplying it,
+// or returns nil if none of the sources supplies the tree topology
+func mergeTrees(sources []*source, trees []map[string]*treeLeaf, nodes map[string]string) (*topology.Vertex, []*conflict) {
+	if !slices.ContainsFunc(trees, func(t map[string]*treeLeaf) bool { return t != nil }) {
+		return nil, nil
+	}
+
+	groupings := make([]*grouping, len(trees))
+	for i, tree := range trees {
+		groupings[i] = newGrouping(tree, (*treeLeaf).leafSwitch)
+	}
+
+	type switchKey struct{ source, id string }
+	switches := make(map[switchKey]*topology.Vertex)
+	// owners maps the switch IDs to the source that first used them
+	owners := make(map[string]string)
+
+	treeRoot := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+	var conflicts []*conflict
+
+	for _, instance := range slices.Sorted(maps.Keys(nodes)) {
+		name := nodes[instance]
+
+		selected := -1
+		for i, tree := range trees {
+			if _, ok := tree[instance]; ok {
+				if selected < 0 {
+					selected = i
+				} else if detail, ok := groupings[selected].mismatch(instance, groupings[i], nodes, "switch"); ok {
+					conflicts = append(conflicts, &conflict{
+						topology: RoleTree,
+						provider: sources[i].name,
+						selected: sources[selected].name,
+						node:     name,
+						detail:   detail,
+					})
+				}
+			}
+		}
+
+		if selected < 0 {
+			addNoTopology(treeRoot, instance, name)
+			continue
+		}
+
+		// rebuild the switch path of the selected source
+		src := sources[selected].name
+		parent := treeRoot
+		for _, sw := range trees[selected][instance].path {
+			key := switchKey{source: src, id: sw.ID}
+			v, ok := switches[key]
+			if !ok {
+				id := sw.ID
+				// switch IDs used by several sources are prefixed with the source name
+				if owner, used := owners[id]; used && owner != src {
+					id = src + "/" + id
+				} else {
+					owners[id] = src
+				}
+				v = &topology.Vertex{ID: id, Name: sw.Name, Vertices: make(map[string]*topology.Vertex)}
+				switches[key] = v
+			}
+			parent.Vertices[v.ID] = v
+			parent = v
+		}
+		parent.Vertices[instance] = &topology.Vertex{ID: instance, Name: name}
+	}
+
+	return treeRoot, conflicts
+}
+
+// addNoTopology adds the node to the switch holding the nodes without topology
+func addNoTopology(treeRoot *topology.Vertex, instance, name string) {
+	sw, ok := treeRoot.Vertices[topology.NoTopology]
+	if !ok {
+		sw = &topology.Vertex{ID: topology.NoTopology, Vertices: make(map[string]*topology.Vertex)}
+		treeRoot.Vertices[topology.NoTopology] = sw
+	}
+	sw.Vertices[instance] = &topology.Vertex{ID: instance, Name: name}
+	metrics.SetMissingTopology(NAME, name)
+}
+
+// mergeBlocks builds the block topology from the sources supplying it,
+// or returns nil if none of the nodes is in a block
+func mergeBlocks(sources []*source, blocks []map[string]*blockLeaf, nodes map[string]string) (*topology.Vertex, []*conflict) {
+	domainMap := topology.NewDomainMap()
+	var conflicts []*conflict
+
+	groupings := make([]*grouping, len(blocks))
+	for i, b := range blocks {
+		groupings[i] = newGrouping(b, func(leaf *blockLeaf) string { return leaf.domain })
+	}
+
+	for _, instance := range slices.Sorted(maps.Keys(nodes)) {
+		name := nodes[instance]
+
+		selected := -1
+		for i, b := range blocks {
+			if _, ok := b[instance]; ok {
+				if selected < 0 {
+					selected = i
+				} else if detail, ok := groupings[selected].mismatch(instance, groupings[i], nodes, "domain"); ok {
+					conflicts = append(conflicts, &conflict{
+						topology: RoleBlock,
+						provider: sources[i].name,
+						selected: sources[selected].name,
+						node:     name,
+						detail:   detail,
+					})
+				}
+			}
+		}
+
+		if selected < 0 {
+			continue
+		}
+
+		domainMap.AddHost(blocks[selected][instance].domain, instance, name)
+	}
+
+	if len(domainMap) == 0 {
+		return nil, conflicts
+	}
+
+	return domainMap.ToBlocks(), conflicts
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package composite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "composite"
+
+	RoleTree  = "tree"
+	RoleBlock = "block"
+	RoleBoth  = "both"
+)
+
+type Provider struct {
+	// sources lists the sub-providers in the order of precedence
+	sources []*source
+}
+
+type source struct {
+	name     string
+	role     string
+	provider providers.Provider
+}
+
+type Params struct {
+	// Providers lists the sub-providers
+	Providers []SubProvider `mapstructure:"providers"`
+	// Precedence (optional) lists the sub-provider names in the order of precedence
+	// used to resolve conflicts; defaults to the order of Providers
+	Precedence []string `mapstructure:"precedence"`
+}
+
+type SubProvider struct {
+	// Name specifies the registered provider name
+	Name string `mapstructure:"name"`
+	// Role (optional) specifies the topology supplied by the provider:
+	// "tree", "block" or "both" (default)
+	Role string `mapstructure:"role"`
+	// Params (optional) specifies the provider parameters
+	Params map[string]any `mapstructure:"params"`
+}
+
+// NamedLoader returns the composite provider loader, which loads the sub-providers from the registry
+func NamedLoader(reg providers.Registry) providers.NamedLoader {
+	return func() (string, providers.Loader) {
+		return NAME, Loader(reg)
+	}
+}
+
+func Loader(reg providers.Registry) providers.Loader {
+	return func(ctx context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+		p, err := getParameters(cfg.Params)
+		if err != nil {
+			return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+		}
+
+		sources := make([]*source, 0, len(p.Providers))
+		for _, name := range p.Precedence {
+			i := slices.IndexFunc(p.Providers, func(sp SubProvider) bool { return sp.Name == name })
+			sp := p.Providers[i]
+
+			loader, httpErr := reg.Get(sp.Name)
+			if httpErr != nil {
+				return nil, httpErr
+			}
+
+			prv, httpErr := loader(ctx, providers.Config{
+				Creds:  providers.SubProviderCredentials(cfg.Creds, sp.Name),
+				Params: sp.Params,
+			})
+			if httpErr != nil {
+				return nil, httperr.NewError(httpErr.Code(), fmt.Sprintf("provider %s: %s", sp.Name, httpErr.Error()))
+			}
+
+			sources = append(sources, &source{name: sp.Name, role: sp.Role, provider: prv})
+		}
+
+		return &Provider{sources: sources}, nil
+	}
+}
+
+func getParameters(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if len(p.Providers) == 0 {
+		return nil, fmt.Errorf("missing providers parameter")
+	}
+
+	names := make([]string, 0, len(p.Providers))
+	for i := range p.Providers {
+		sp := &p.Providers[i]
+		switch {
+		case len(sp.Name) == 0:
+			return nil, fmt.Errorf("missing provider name")
+		case sp.Name == NAME:
+			return nil, fmt.Errorf("nested %s provider is not supported", NAME)
+		case slices.Contains(names, sp.Name):
+			return nil, fmt.Errorf("duplicate provider %s", sp.Name)
+		}
+		names = append(names, sp.Name)
+
+		switch sp.Role {
+		case "":
+			sp.Role = RoleBoth
+		case RoleTree, RoleBlock, RoleBoth:
+		default:
+			return nil, fmt.Errorf("unsupported role %q of provider %s", sp.Role, sp.Name)
+		}
+	}
+
+	// the providers missing in the precedence list follow in the list order
+	for _, name := range p.Precedence {
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("unknown provider %s in precedence", name)
+		}
+	}
+	precedence := slices.Clone(p.Precedence)
+	for _, name := range names {
+		if !slices.Contains(precedence, name) {
+			precedence = append(precedence, name)
+		}
+	}
+	if len(precedence) != len(names) {
+		return nil, fmt.Errorf("duplicate providers in precedence %v", p.Precedence)
+	}
+	p.Precedence = precedence
+
+	return p, nil
+}
+
+// Engine support
+
+// Instances2NodeMap implements engines.instanceMapper
+func (p *Provider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
+	m, err := p.instanceMapper()
+	if err != nil {
+		return nil, err
+	}
+	return m.Instances2NodeMap(ctx, nodes)
+}
+
+// GetInstancesRegions implements engines.instanceMapper
+func (p *Provider) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
+	m, err := p.instanceMapper()
+	if err != nil {
+		return nil, err
+	}
+	return m.GetInstancesRegions(ctx, nodes)
+}
+
+type instanceMapper interface {
+	Instances2NodeMap(context.Context, []string) (map[string]string, error)
+	GetInstancesRegions(context.Context, []string) (map[string]string, error)
+}
+
+// instanceMapper returns the highest-precedence sub-provider mapping the nodes to instances
+func (p *Provider) instanceMapper() (instanceMapper, error) {
+	for _, src := range p.sources {
+		if m, ok := src.provider.(instanceMapper); ok {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("none of the %s sub-providers maps nodes to instances", NAME)
+}
+
+func (p *Provider) GenerateTopologyConfig(ctx context.Context, pageSize *int, instances []topology.ComputeInstances) (*topology.Vertex, *httperr.Error) {
+	roots := make([]*topology.Vertex, len(p.sources))
+	errs := make([]*httperr.Error, len(p.sources))
+
+	var wg sync.WaitGroup
+	for i, src := range p.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			klog.V(4).Infof("Generating topology with provider %s", src.name)
+			roots[i], errs[i] = src.provider.GenerateTopologyConfig(ctx, pageSize, instances)
+		}()
+	}
+	wg.Wait()
+
+	// under the partial results policy, the server errors of the sub-providers are reported as warnings
+	// unless all sub-providers fail
+	var warnings []topology.Warning
+	var failures []*httperr.Error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		err = httperr.NewError(err.Code(), fmt.Sprintf("provider %s: %s", p.sources[i].name, err.Error()))
+		if !providers.Tolerable(ctx, err.Code()) {
+			return nil, err
+		}
+		roots[i] = nil
+		failures = append(failures, err)
+		warnings = append(warnings, topology.Warning{Kind: topology.WarningProvider, Message: err.Error()})
+	}
+	if len(failures) == len(p.sources) {
+		return nil, failures[0]
+	}
+
+	root, conflicts := merge(p.sources, roots, instances)
+
+	for _, r := range roots {
+		if r != nil {
+			warnings = append(warnings, r.Warnings...)
+		}
+	}
+	for _, c := range conflicts {
+		klog.Warning(c.String())
+		metrics.AddCompositeConflict(c.provider, c.topology, ConflictMismatch)
+		warnings = append(warnings, c.warning())
+	}
+	root.Warnings = warnings
+
+	return root, nil
+}
@@ -0,0 +1,330 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package composite
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/component"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+type staticProvider struct {
+	root  *topology.Vertex
+	err   *httperr.Error
+	creds map[string]string
+}
+
+func (p *staticProvider) GenerateTopologyConfig(_ context.Context, _ *int, _ []topology.ComputeInstances) (*topology.Vertex, *httperr.Error) {
+	return p.root, p.err
+}
+
+func staticLoader(prv *staticProvider) providers.Loader {
+	return func(_ context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+		prv.creds = cfg.Creds
+		return prv, nil
+	}
+}
+
+func node(instance, name string) *topology.Vertex {
+	return &topology.Vertex{ID: instance, Name: name}
+}
+
+func vertex(id string, children ...*topology.Vertex) *topology.Vertex {
+	v := &topology.Vertex{ID: id, Vertices: make(map[string]*topology.Vertex)}
+	for _, w := range children {
+		v.Vertices[w.ID] = w
+	}
+	return v
+}
+
+func blocks(domains map[string][]*topology.Vertex) *topology.Vertex {
+	dm := topology.NewDomainMap()
+	for domain, nodes := range domains {
+		for _, n := range nodes {
+			dm.AddHost(domain, n.ID, n.Name)
+		}
+	}
+	return dm.ToBlocks()
+}
+
+func testSources() (*staticProvider, *staticProvider, *staticProvider) {
+	ib := &staticProvider{root: &topology.Vertex{Vertices: map[string]*topology.Vertex{
+		topology.TopologyTree: vertex("",
+			vertex("core", vertex("leaf1", node("i1", "n1"), node("i2", "n2"))),
+			vertex(topology.NoTopology, node("i3", "n3")),
+		),
+	}}}
+
+	netq := &staticProvider{root: &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: vertex("",
+				vertex("core", vertex("leaf2", node("i1", "n1"), node("i3", "n3")), vertex("leaf3", node("i2", "n2"))),
+			),
+			topology.TopologyBlock: blocks(map[string][]*topology.Vertex{
+				"nvl1": {node("i1", "n1"), node("i2", "n2")},
+			}),
+		},
+		Metadata: map[string]string{topology.KeyGeneratedAt: "netq"},
+	}}
+
+	dra := &staticProvider{root: &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyBlock: blocks(map[string][]*topology.Vertex{
+				"nvl1": {node("i1", "n1")},
+				"nvl2": {node("i2", "n2"), node("i3", "n3")},
+			}),
+		},
+		Metadata: map[string]string{topology.KeyGeneratedAt: "dra"},
+	}}
+
+	return ib, netq, dra
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		ret    *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: missing providers",
+			params: map[string]any{},
+			err:    "missing providers parameter",
+		},
+		{
+			name: "Case 2: missing provider name",
+			params: map[string]any{
+				"providers": []any{map[string]any{"role": "tree"}},
+			},
+			err: "missing provider name",
+		},
+		{
+			name: "Case 3: duplicate provider",
+			params: map[string]any{
+				"providers": []any{map[string]any{"name": "netq"}, map[string]any{"name": "netq"}},
+			},
+			err: "duplicate provider netq",
+		},
+		{
+			name: "Case 4: nested composite provider",
+			params: map[string]any{
+				"providers": []any{map[string]any{"name": "composite"}},
+			},
+			err: "nested composite provider is not supported",
+		},
+		{
+			name: "Case 5: unsupported role",
+			params: map[string]any{
+				"providers": []any{map[string]any{"name": "netq", "role": "flat"}},
+			},
+			err: `unsupported role "flat" of provider netq`,
+		},
+		{
+			name: "Case 6: unknown provider in precedence",
+			params: map[string]any{
+				"providers":  []any{map[string]any{"name": "netq"}},
+				"precedence": []any{"dra"},
+			},
+			err: "unknown provider dra in precedence",
+		},
+		{
+			name: "Case 7: duplicate precedence",
+			params: map[string]any{
+				"providers":  []any{map[string]any{"name": "netq"}, map[string]any{"name": "dra"}},
+				"precedence": []any{"dra", "dra"},
+			},
+			err: "duplicate providers in precedence [dra dra]",
+		},
+		{
+			name: "Case 8: valid input",
+			params: map[string]any{
+				"providers": []any{
+					map[string]any{"name": "infiniband-bm", "role": "tree"},
+					map[string]any{"name": "netq"},
+					map[string]any{"name": "dra", "role": "block", "params": map[string]any{"nodeSelector": map[string]any{"a": "b"}}},
+				},
+				"precedence": []any{"dra"},
+			},
+			ret: &Params{
+				Providers: []SubProvider{
+					{Name: "infiniband-bm", Role: RoleTree},
+					{Name: "netq", Role: RoleBoth},
+					{Name: "dra", Role: RoleBlock, Params: map[string]any{"nodeSelector": map[string]any{"a": "b"}}},
+				},
+				Precedence: []string{"dra", "infiniband-bm", "netq"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.ret, p)
+			}
+		})
+	}
+}
+
+func TestLoader(t *testing.T) {
+	ib, netq, dra := testSources()
+	reg := providers.Registry(component.NewRegistry(
+		component.Named("ib", staticLoader(ib)),
+		component.Named("netq", staticLoader(netq)),
+		component.Named("dra", staticLoader(dra)),
+	))
+
+	params := map[string]any{
+		"providers": []any{
+			map[string]any{"name": "ib", "role": "tree"},
+			map[string]any{"name": "netq"},
+			map[string]any{"name": "dra", "role": "block"},
+		},
+		"precedence": []any{"netq"},
+	}
+	creds := map[string]string{"netq.username": "user", "netq.password": "secret", "token": "x"}
+
+	prv, err := Loader(reg)(context.TODO(), providers.Config{Creds: creds, Params: params})
+	require.Nil(t, err)
+
+	var names []string
+	for _, src := range prv.(*Provider).sources {
+		names = append(names, src.name+":"+src.role)
+	}
+	require.Equal(t, []string{"netq:both", "ib:tree", "dra:block"}, names)
+	require.Equal(t, map[string]string{"username": "user", "password": "secret"}, netq.creds)
+	require.Nil(t, ib.creds)
+
+	params["providers"] = []any{map[string]any{"name": "aws"}}
+	params["precedence"] = nil
+	_, err = Loader(reg)(context.TODO(), providers.Config{Params: params})
+	require.Equal(t, httperr.NewError(http.StatusBadRequest, `unsupported provider "aws"`), err)
+
+	_, err = Loader(reg)(context.TODO(), providers.Config{})
+	require.Equal(t, httperr.NewError(http.StatusBadRequest, "missing providers parameter"), err)
+}
+
+func TestGenerateTopologyConfig(t *testing.T) {
+	ib, netq, dra := testSources()
+	prv := &Provider{sources: []*source{
+		{name: "ib", role: RoleTree, provider: ib},
+		{name: "netq", role: RoleBoth, provider: netq},
+		{name: "dra", role: RoleBlock, provider: dra},
+	}}
+	instances := []topology.ComputeInstances{
+		{Instances: map[string]string{"i1": "n1", "i2": "n2", "i3": "n3", "i4": "n4"}},
+	}
+
+	root, err := prv.GenerateTopologyConfig(context.TODO(), nil, instances)
+	require.Nil(t, err)
+
+	expected := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: vertex("",
+				vertex("core", vertex("leaf1", node("i1", "n1"), node("i2", "n2"))),
+				vertex("netq/core", vertex("leaf2", node("i3", "n3"))),
+				vertex(topology.NoTopology, node("i4", "n4")),
+			),
+			topology.TopologyBlock: blocks(map[string][]*topology.Vertex{
+				"nvl1": {node("i1", "n1"), node("i2", "n2")},
+				"nvl2": {node("i3", "n3")},
+			}),
+		},
+		Metadata: map[string]string{topology.KeyGeneratedAt: "netq"},
+		// the conflicts are reported as warnings
+		Warnings: []topology.Warning{
+			{Kind: topology.WarningConflict, Node: "n1",
+				Message: "tree topology of node n1 differs in provider netq (switch leaf2 shared with no nodes instead of n2); using provider ib"},
+			{Kind: topology.WarningConflict, Node: "n2",
+				Message: "tree topology of node n2 differs in provider netq (switch leaf3 shared with no nodes instead of n1); using provider ib"},
+			{Kind: topology.WarningConflict, Node: "n1",
+				Message: "block topology of node n1 differs in provider dra (domain nvl1 shared with no nodes instead of n2); using provider netq"},
+			{Kind: topology.WarningConflict, Node: "n2",
+				Message: "block topology of node n2 differs in provider dra (domain nvl2 shared with no nodes instead of n1); using provider netq"},
+		},
+	}
+	require.Equal(t, expected, root)
+
+	dra.err = httperr.NewError(http.StatusBadGateway, "failed to list nodes")
+	_, err = prv.GenerateTopologyConfig(context.TODO(), nil, instances)
+	require.Equal(t, httperr.NewError(http.StatusBadGateway, "provider dra: failed to list nodes"), err)
+
+	// under the partial results policy, the failed provider is reported as a warning
+	netq.root.Warnings = []topology.Warning{topology.MissingTopologyWarning("", "n2", "switch data")}
+	ctx := providers.WithPartialResults(context.TODO(), true)
+	root, err = prv.GenerateTopologyConfig(ctx, nil, instances)
+	require.Nil(t, err)
+	require.Equal(t, []topology.Warning{
+		{Kind: topology.WarningProvider, Message: "provider dra: failed to list nodes"},
+		{Kind: topology.WarningTopology, Node: "n2", Message: "node n2 missing switch data"},
+		{Kind: topology.WarningConflict, Node: "n1",
+			Message: "tree topology of node n1 differs in provider netq (switch leaf2 shared with no nodes instead of n2); using provider ib"},
+		{Kind: topology.WarningConflict, Node: "n2",
+			Message: "tree topology of node n2 differs in provider netq (switch leaf3 shared with no nodes instead of n1); using provider ib"},
+	}, root.Warnings)
+	require.Equal(t, netq.root.Vertices[topology.TopologyBlock], root.Vertices[topology.TopologyBlock])
+
+	// the request fails if all providers fail
+	ib.err = httperr.NewError(http.StatusBadGateway, "ibnetdiscover failed")
+	netq.err = httperr.NewError(http.StatusBadGateway, "API error")
+	_, err = prv.GenerateTopologyConfig(ctx, nil, instances)
+	require.Equal(t, httperr.NewError(http.StatusBadGateway, "provider ib: ibnetdiscover failed"), err)
+}
+
+func TestMergeConflicts(t *testing.T) {
+	sources := []*source{
+		{name: "ib", role: RoleBoth},
+		{name: "netq", role: RoleTree},
+		{name: "dra", role: RoleBlock},
+	}
+	roots := []*topology.Vertex{
+		{Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: vertex("",
+				vertex("S1", node("i1", "n1"), node("i2", "n2")),
+				vertex("S2", node("i3", "n3"), node("i4", "n4")),
+			),
+			topology.TopologyBlock: blocks(map[string][]*topology.Vertex{
+				"nvl1": {node("i1", "n1"), node("i2", "n2")},
+			}),
+		}},
+		// same switches under other IDs
+		{Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: vertex("",
+				vertex("spine", vertex("leaf-a", node("i1", "n1"), node("i2", "n2")), vertex("leaf-b", node("i3", "n3"))),
+			),
+		}},
+		// the CPU nodes are not in any NVLink domain
+		{Vertices: map[string]*topology.Vertex{
+			topology.TopologyBlock: blocks(map[string][]*topology.Vertex{
+				"clique-1": {node("i1", "n1"), node("i2", "n2")},
+			}),
+		}},
+	}
+	instances := []topology.ComputeInstances{
+		{Instances: map[string]string{"i1": "n1", "i2": "n2", "i3": "n3", "i4": "n4"}},
+	}
+
+	_, conflicts := merge(sources, roots, instances)
+	require.Empty(t, conflicts)
+}
+
+func TestInstanceMapper(t *testing.T) {
+	prv := &Provider{sources: []*source{{name: "netq", role: RoleBoth, provider: &staticProvider{}}}}
+
+	_, err := prv.Instances2NodeMap(context.TODO(), []string{"n1"})
+	require.EqualError(t, err, "none of the composite sub-providers maps nodes to instances")
+}
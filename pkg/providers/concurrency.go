@@ -0,0 +1,94 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// DefaultMaxConcurrency is the default maximal number of regions, zones or nodes queried concurrently
+const DefaultMaxConcurrency = 4
+
+type maxConcurrencyKey struct{}
+
+// WithMaxConcurrency returns the context carrying the maximal number of concurrent queries;
+// nil keeps the default
+func WithMaxConcurrency(ctx context.Context, maxConcurrency *int) context.Context {
+	if maxConcurrency == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, maxConcurrencyKey{}, *maxConcurrency)
+}
+
+// MaxConcurrency returns the maximal number of concurrent queries carried by the context
+func MaxConcurrency(ctx context.Context) int {
+	if n, ok := ctx.Value(maxConcurrencyKey{}).(int); ok && n > 0 {
+		return n
+	}
+	return DefaultMaxConcurrency
+}
+
+// RegionTopologyFunc adds the topology of the compute instances of a region to the cluster topology
+type RegionTopologyFunc func(ctx context.Context, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error
+
+// GenerateInstanceTopology calls fn for the regions concurrently, bounded by MaxConcurrency,
+// and merges the region topologies in the order of the regions.
+// The first failure cancels the context of the other regions and is returned.
+// Under the partial results policy, the server errors of the regions are reported as warnings
+// unless all regions fail.
+func GenerateInstanceTopology(ctx context.Context, cis []topology.ComputeInstances, fn RegionTopologyFunc) (*topology.ClusterTopology, *httperr.Error) {
+	topos := make([]*topology.ClusterTopology, len(cis))
+	failures := make([]*httperr.Error, len(cis))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(MaxConcurrency(ctx))
+	for i := range cis {
+		g.Go(func() error {
+			topo := topology.NewClusterTopology()
+			if err := fn(gctx, &cis[i], topo); err != nil {
+				if Tolerable(ctx, err.Code()) {
+					failures[i] = err
+					return nil
+				}
+				return err
+			}
+			topos[i] = topo
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		var httpErr *httperr.Error
+		if errors.As(err, &httpErr) {
+			return nil, httpErr
+		}
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if len(cis) != 0 && !slices.Contains(failures, nil) {
+		return nil, failures[0]
+	}
+
+	topo := topology.NewClusterTopology()
+	for i, t := range topos {
+		if err := failures[i]; err != nil {
+			topo.AddWarning(topology.RegionWarning(cis[i].Region, err))
+			continue
+		}
+		topo.Instances = append(topo.Instances, t.Instances...)
+		topo.Warnings = append(topo.Warnings, t.Warnings...)
+	}
+
+	return topo, nil
+}
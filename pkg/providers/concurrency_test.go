@@ -0,0 +1,118 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package providers
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agrea/ptr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestMaxConcurrency(t *testing.T) {
+	ctx := context.TODO()
+	require.Equal(t, DefaultMaxConcurrency, MaxConcurrency(ctx))
+	require.Equal(t, DefaultMaxConcurrency, MaxConcurrency(WithMaxConcurrency(ctx, nil)))
+	require.Equal(t, 2, MaxConcurrency(WithMaxConcurrency(ctx, ptr.Int(2))))
+}
+
+func TestGenerateInstanceTopology(t *testing.T) {
+	cis := []topology.ComputeInstances{
+		{Region: "r1", Instances: map[string]string{"i1": "n1"}},
+		{Region: "r2", Instances: map[string]string{"i2": "n2"}},
+		{Region: "r3", Instances: map[string]string{"i3": "n3"}},
+		{Region: "r4", Instances: map[string]string{"i4": "n4"}},
+	}
+
+	// Case 1: the regions are merged in order, with bounded concurrency
+	var running, maxRunning atomic.Int32
+	ctx := WithMaxConcurrency(context.TODO(), ptr.Int(2))
+	topo, err := GenerateInstanceTopology(ctx, cis, func(_ context.Context, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		// the first regions finish last
+		time.Sleep(time.Duration(len(cis)-int(ci.Region[1]-'0')) * 10 * time.Millisecond)
+		for instance := range ci.Instances {
+			topo.Append(&topology.InstanceTopology{InstanceID: instance, BlockID: ci.Region})
+		}
+		return nil
+	})
+	require.Nil(t, err)
+	require.LessOrEqual(t, maxRunning.Load(), int32(2))
+	require.Equal(t, []*topology.InstanceTopology{
+		{InstanceID: "i1", BlockID: "r1"},
+		{InstanceID: "i2", BlockID: "r2"},
+		{InstanceID: "i3", BlockID: "r3"},
+		{InstanceID: "i4", BlockID: "r4"},
+	}, topo.Instances)
+
+	// Case 2: a failed region cancels the others
+	_, err = GenerateInstanceTopology(context.TODO(), cis, func(ctx context.Context, ci *topology.ComputeInstances, _ *topology.ClusterTopology) *httperr.Error {
+		if ci.Region == "r2" {
+			return httperr.NewError(http.StatusBadGateway, "API error")
+		}
+		select {
+		case <-ctx.Done():
+			return httperr.NewError(http.StatusBadGateway, ctx.Err().Error())
+		case <-time.After(10 * time.Second):
+			return nil
+		}
+	})
+	require.Equal(t, httperr.NewError(http.StatusBadGateway, "API error"), err)
+
+	// Case 3: the failed region is reported as a warning under the partial results policy
+	ctx = WithPartialResults(context.TODO(), true)
+	topo, err = GenerateInstanceTopology(ctx, cis, func(_ context.Context, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error {
+		switch ci.Region {
+		case "r2":
+			return httperr.NewError(http.StatusBadGateway, "API error")
+		case "r3":
+			topo.AddWarning(topology.MissingTopologyWarning(ci.Region, "n3", "PhysicalHostTopology"))
+			return nil
+		}
+		for instance := range ci.Instances {
+			topo.Append(&topology.InstanceTopology{InstanceID: instance, BlockID: ci.Region})
+		}
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, []*topology.InstanceTopology{
+		{InstanceID: "i1", BlockID: "r1"},
+		{InstanceID: "i4", BlockID: "r4"},
+	}, topo.Instances)
+	require.Equal(t, []topology.Warning{
+		{Kind: topology.WarningRegion, Region: "r2", Message: "region r2 failed: API error"},
+		{Kind: topology.WarningTopology, Region: "r3", Node: "n3", Message: "node n3 missing PhysicalHostTopology"},
+	}, topo.Warnings)
+
+	// Case 4: client errors fail the request under the partial results policy
+	_, err = GenerateInstanceTopology(ctx, cis, func(_ context.Context, ci *topology.ComputeInstances, _ *topology.ClusterTopology) *httperr.Error {
+		if ci.Region == "r2" {
+			return httperr.NewError(http.StatusBadRequest, "must specify region")
+		}
+		return nil
+	})
+	require.Equal(t, httperr.NewError(http.StatusBadRequest, "must specify region"), err)
+
+	// Case 5: all regions fail under the partial results policy
+	_, err = GenerateInstanceTopology(ctx, cis, func(_ context.Context, ci *topology.ComputeInstances, _ *topology.ClusterTopology) *httperr.Error {
+		return httperr.NewError(http.StatusBadGateway, "API error in "+ci.Region)
+	})
+	require.Equal(t, httperr.NewError(http.StatusBadGateway, "API error in r1"), err)
+}
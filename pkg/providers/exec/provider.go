@@ -0,0 +1,118 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package exec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines/json"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// Provider runs an external plugin, which receives the Request on stdin
+// and writes the topology document to stdout
+type Provider struct {
+	plugin *exec.Plugin
+	config providers.Config
+}
+
+// Request is the plugin request of the exec provider
+type Request struct {
+	Version   string                      `json:"version"`
+	PageSize  *int                        `json:"pageSize,omitempty"`
+	Instances []topology.ComputeInstances `json:"instances"`
+	Params    map[string]any              `json:"params,omitempty"`
+	Creds     map[string]string           `json:"creds,omitempty"`
+}
+
+// NamedLoader returns the loader of the provider plugin, registered under the plugin name
+func NamedLoader(plugin *exec.Plugin) providers.NamedLoader {
+	return func() (string, providers.Loader) {
+		return plugin.Name, Loader(plugin)
+	}
+}
+
+func Loader(plugin *exec.Plugin) providers.Loader {
+	return func(_ context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+		return &Provider{plugin: plugin, config: cfg}, nil
+	}
+}
+
+func (p *Provider) GenerateTopologyConfig(ctx context.Context, pageSize *int, instances []topology.ComputeInstances) (*topology.Vertex, *httperr.Error) {
+	if instances == nil {
+		instances = []topology.ComputeInstances{}
+	}
+
+	data, httpErr := p.plugin.Run(ctx, &Request{
+		Version:   exec.PluginVersion,
+		PageSize:  pageSize,
+		Instances: instances,
+		Params:    p.config.Params,
+		Creds:     p.config.Creds,
+	})
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	doc, err := json.ParseDocument(data)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("invalid output of plugin %s: %v", p.plugin.Name, err))
+	}
+
+	root, err := doc.ToGraph()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("invalid output of plugin %s: %v", p.plugin.Name, err))
+	}
+
+	p.addMissingNodes(root, instances)
+
+	return root, nil
+}
+
+// addMissingNodes places the requested nodes missing in the tree topology under the "no-topology" switch
+func (p *Provider) addMissingNodes(root *topology.Vertex, instances []topology.ComputeInstances) {
+	tree, ok := root.Vertices[topology.TopologyTree]
+	if !ok {
+		return
+	}
+
+	found := make(map[string]bool)
+	var walk func(v *topology.Vertex)
+	walk = func(v *topology.Vertex) {
+		for _, w := range v.Vertices {
+			if len(w.Vertices) == 0 {
+				found[w.ID] = true
+			} else {
+				walk(w)
+			}
+		}
+	}
+	walk(tree)
+
+	for _, ci := range instances {
+		for instance, node := range ci.Instances {
+			if found[instance] {
+				continue
+			}
+			sw, ok := tree.Vertices[topology.NoTopology]
+			if !ok {
+				sw = &topology.Vertex{ID: topology.NoTopology, Vertices: make(map[string]*topology.Vertex)}
+				tree.Vertices[topology.NoTopology] = sw
+			}
+			klog.V(4).Infof("Adding node %s w/o topology", node)
+			sw.Vertices[instance] = &topology.Vertex{ID: instance, Name: node}
+			metrics.SetMissingTopology(p.plugin.Name, node)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package exec
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const testDocument = `{
+  "version": "v1",
+  "nodes": [
+    {"name": "node1", "instanceId": "i1", "switches": ["leaf"], "block": "block001"},
+    {"name": "node2", "instanceId": "i2", "switches": ["leaf"]}
+  ],
+  "switches": [
+    {"id": "spine", "switches": ["leaf"]},
+    {"id": "leaf", "parent": "spine", "nodes": ["node1", "node2"]}
+  ],
+  "blocks": [
+    {"id": "block001", "name": "nvl1", "nodes": ["node1"]}
+  ]
+}`
+
+func testProvider(t *testing.T, script string) providers.Provider {
+	plugin := &exec.Plugin{Name: "inventory", Command: "/bin/sh", Args: []string{"-c", script}, Timeout: time.Second}
+	name, loader := NamedLoader(plugin)()
+	require.Equal(t, "inventory", name)
+
+	prv, err := loader(context.TODO(), providers.Config{
+		Creds:  map[string]string{"token": "secret"},
+		Params: map[string]any{"site": "a"},
+	})
+	require.Nil(t, err)
+	return prv
+}
+
+func TestGenerateTopologyConfig(t *testing.T) {
+	instances := []topology.ComputeInstances{
+		{Region: "r1", Instances: map[string]string{"i1": "node1", "i2": "node2", "i3": "node3"}},
+	}
+
+	// the request is checked by the plugin
+	script := `req=$(cat)
+echo "$req" | grep -q '"creds":{"token":"secret"}' || exit 2
+echo "$req" | grep -q '"params":{"site":"a"}' || exit 2
+cat <<EOF
+` + testDocument + `
+EOF`
+	root, err := testProvider(t, script).GenerateTopologyConfig(context.TODO(), nil, instances)
+	require.Nil(t, err)
+
+	expected := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"spine": {
+						ID: "spine",
+						Vertices: map[string]*topology.Vertex{
+							"leaf": {
+								ID: "leaf",
+								Vertices: map[string]*topology.Vertex{
+									"i1": {ID: "i1", Name: "node1"},
+									"i2": {ID: "i2", Name: "node2"},
+								},
+							},
+						},
+					},
+					topology.NoTopology: {
+						ID:       topology.NoTopology,
+						Vertices: map[string]*topology.Vertex{"i3": {ID: "i3", Name: "node3"}},
+					},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"nvl1": {
+						ID:       "block001",
+						Name:     "nvl1",
+						Vertices: map[string]*topology.Vertex{"node1": {ID: "i1", Name: "node1"}},
+					},
+				},
+			},
+		},
+	}
+	require.Equal(t, expected, root)
+
+	_, err = testProvider(t, `echo '{"version":"v2"}'`).GenerateTopologyConfig(context.TODO(), nil, instances)
+	require.Equal(t, httperr.NewError(http.StatusBadGateway,
+		`invalid output of plugin inventory: unsupported topology document version "v2"`), err)
+
+	_, err = testProvider(t, `echo '{"version":"v1","switches":[{"id":"leaf","parent":"spine"}]}'`).GenerateTopologyConfig(context.TODO(), nil, instances)
+	require.Equal(t, httperr.NewError(http.StatusBadGateway,
+		`invalid output of plugin inventory: unknown parent switch "spine" of switch "leaf"`), err)
+
+	_, err = testProvider(t, `echo "missing token" >&2; exit 3`).GenerateTopologyConfig(context.TODO(), nil, instances)
+	require.Equal(t, httperr.NewError(http.StatusUnauthorized, "plugin inventory failed with exit code 3: missing token"), err)
+}
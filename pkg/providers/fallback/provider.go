@@ -0,0 +1,318 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fallback
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "fallback"
+
+	// LastKnown is the provider recorded when the last known topology is returned
+	LastKnown = "last-known"
+)
+
+// lastKnown holds the last topology generated successfully by the list of providers
+// with their parameters
+var lastKnown = struct {
+	sync.Mutex
+	roots map[string]*topology.Vertex
+}{roots: make(map[string]*topology.Vertex)}
+
+type Provider struct {
+	candidates []*candidate
+	// key identifies the provider list with their parameters
+	key       string
+	lastKnown bool
+}
+
+type candidate struct {
+	name     string
+	provider providers.Provider
+	// err is the loader error of the provider, which is skipped if set
+	err *httperr.Error
+}
+
+type Params struct {
+	// Providers lists the providers in the order they are tried
+	Providers []SubProvider `mapstructure:"providers"`
+	// LastKnown (optional) returns the last topology generated successfully
+	// if all providers fail
+	LastKnown bool `mapstructure:"lastKnown"`
+}
+
+type SubProvider struct {
+	// Name specifies the registered provider name
+	Name string `mapstructure:"name"`
+	// Params (optional) specifies the provider parameters
+	Params map[string]any `mapstructure:"params"`
+}
+
+// NamedLoader returns the fallback provider loader, which loads the providers from the registry
+func NamedLoader(reg providers.Registry) providers.NamedLoader {
+	return func() (string, providers.Loader) {
+		return NAME, Loader(reg)
+	}
+}
+
+func Loader(reg providers.Registry) providers.Loader {
+	return func(ctx context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+		p, err := getParameters(cfg.Params)
+		if err != nil {
+			return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+		}
+
+		prv := &Provider{
+			candidates: make([]*candidate, 0, len(p.Providers)),
+			lastKnown:  p.LastKnown,
+		}
+		keys := make([]string, 0, len(p.Providers))
+
+		for _, sp := range p.Providers {
+			keys = append(keys, sp.Name+":"+providers.ParamsHash(sp.Params))
+
+			loader, httpErr := reg.Get(sp.Name)
+			if httpErr != nil {
+				return nil, httpErr
+			}
+
+			// a provider failing to load, e.g. because of missing credentials, is skipped
+			c := &candidate{name: sp.Name}
+			c.provider, c.err = loader(ctx, providers.Config{
+				Creds:  providers.SubProviderCredentials(cfg.Creds, sp.Name),
+				Params: sp.Params,
+			})
+			prv.candidates = append(prv.candidates, c)
+		}
+		prv.key = strings.Join(keys, ",")
+
+		return prv, nil
+	}
+}
+
+func getParameters(params map[string]any) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if len(p.Providers) == 0 {
+		return nil, fmt.Errorf("missing providers parameter")
+	}
+
+	names := make([]string, 0, len(p.Providers))
+	for _, sp := range p.Providers {
+		switch {
+		case len(sp.Name) == 0:
+			return nil, fmt.Errorf("missing provider name")
+		case sp.Name == NAME:
+			return nil, fmt.Errorf("nested %s provider is not supported", NAME)
+		case slices.Contains(names, sp.Name):
+			return nil, fmt.Errorf("duplicate provider %s", sp.Name)
+		}
+		names = append(names, sp.Name)
+	}
+
+	return p, nil
+}
+
+// Engine support
+
+// Instances2NodeMap implements engines.instanceMapper
+func (p *Provider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
+	return mapInstances(p, func(m instanceMapper) (map[string]string, error) {
+		return m.Instances2NodeMap(ctx, nodes)
+	})
+}
+
+// GetInstancesRegions implements engines.instanceMapper
+func (p *Provider) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
+	return mapInstances(p, func(m instanceMapper) (map[string]string, error) {
+		return m.GetInstancesRegions(ctx, nodes)
+	})
+}
+
+type instanceMapper interface {
+	Instances2NodeMap(context.Context, []string) (map[string]string, error)
+	GetInstancesRegions(context.Context, []string) (map[string]string, error)
+}
+
+// mapInstances calls the mapping function of the providers in order, until one succeeds
+func mapInstances(p *Provider, f func(instanceMapper) (map[string]string, error)) (map[string]string, error) {
+	err := fmt.Errorf("none of the %s providers maps nodes to instances", NAME)
+	for _, c := range p.candidates {
+		m, ok := c.provider.(instanceMapper)
+		if c.err != nil || !ok {
+			continue
+		}
+		var ret map[string]string
+		if ret, err = f(m); err == nil {
+			return ret, nil
+		}
+		klog.Warningf("Provider %s failed to map nodes to instances: %v", c.name, err)
+	}
+	return nil, err
+}
+
+func (p *Provider) GenerateTopologyConfig(ctx context.Context, pageSize *int, instances []topology.ComputeInstances) (*topology.Vertex, *httperr.Error) {
+	var lastErr *httperr.Error
+	msgs := make([]string, 0, len(p.candidates))
+	// the failures of the skipped providers are reported as warnings of the topology
+	var warnings []topology.Warning
+
+	for _, c := range p.candidates {
+		err := c.err
+		if err == nil {
+			var root *topology.Vertex
+			if root, err = c.provider.GenerateTopologyConfig(ctx, pageSize, instances); err == nil {
+				klog.Infof("Generated topology with provider %s", c.name)
+				p.setLastKnown(root)
+				metrics.AddFallbackProvider(c.name)
+				return withProvider(root, c.name, warnings), nil
+			}
+			// invalid requests are not resolved by another provider,
+			// unlike authorization errors or missing resources
+			if err.Code() == http.StatusBadRequest {
+				return nil, httperr.NewError(err.Code(), fmt.Sprintf("provider %s: %s", c.name, err.Error()))
+			}
+		}
+
+		klog.Warningf("Provider %s failed: %s", c.name, err.Error())
+		metrics.AddFallbackFailure(c.name, err.Code())
+		msgs = append(msgs, fmt.Sprintf("%s: %s", c.name, err.Error()))
+		warnings = append(warnings, topology.Warning{
+			Kind:    topology.WarningProvider,
+			Message: fmt.Sprintf("provider %s failed: %s", c.name, err.Error()),
+		})
+		lastErr = err
+	}
+
+	if root := p.getLastKnown(); root != nil {
+		klog.Warningf("All providers failed; returning the last known topology")
+		metrics.AddFallbackProvider(LastKnown)
+		return withProvider(subset(root, instances), LastKnown, warnings), nil
+	}
+
+	return nil, httperr.NewError(lastErr.Code(), "all providers failed: "+strings.Join(msgs, "; "))
+}
+
+// setLastKnown stores a copy of the topology, as the engines modify the returned topology
+func (p *Provider) setLastKnown(root *topology.Vertex) {
+	if !p.lastKnown {
+		return
+	}
+	root = root.Copy()
+	lastKnown.Lock()
+	defer lastKnown.Unlock()
+	lastKnown.roots[p.key] = root
+}
+
+func (p *Provider) getLastKnown() *topology.Vertex {
+	if !p.lastKnown {
+		return nil
+	}
+	lastKnown.Lock()
+	defer lastKnown.Unlock()
+	return lastKnown.roots[p.key]
+}
+
+// subset returns the topology of the requested instances taken from the last known topology.
+// The instances missing in the last known tree topology are placed under the no-topology switch.
+func subset(root *topology.Vertex, instances []topology.ComputeInstances) *topology.Vertex {
+	nodes := make(map[string]string)
+	for _, ci := range instances {
+		maps.Copy(nodes, ci.Instances)
+	}
+	found := make(map[string]bool)
+
+	// prune copies the vertex with the requested instances only, or returns nil if it has none
+	var prune func(v *topology.Vertex) *topology.Vertex
+	prune = func(v *topology.Vertex) *topology.Vertex {
+		ret := &topology.Vertex{ID: v.ID, Name: v.Name, Metadata: maps.Clone(v.Metadata), Vertices: make(map[string]*topology.Vertex)}
+		for key, w := range v.Vertices {
+			if len(w.Vertices) != 0 {
+				if sub := prune(w); sub != nil {
+					ret.Vertices[key] = sub
+				}
+			} else if name, ok := nodes[w.ID]; ok {
+				ret.Vertices[key] = &topology.Vertex{ID: w.ID, Name: name}
+				found[w.ID] = true
+			}
+		}
+		if len(ret.Vertices) == 0 {
+			return nil
+		}
+		return ret
+	}
+
+	ret := &topology.Vertex{
+		Vertices: make(map[string]*topology.Vertex),
+		Metadata: maps.Clone(root.Metadata),
+		Warnings: slices.Clone(root.Warnings),
+	}
+
+	if tree, ok := root.Vertices[topology.TopologyTree]; ok {
+		treeRoot := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		for key, v := range tree.Vertices {
+			if v.ID == topology.NoTopology {
+				continue
+			}
+			if sub := prune(v); sub != nil {
+				treeRoot.Vertices[key] = sub
+			}
+		}
+		for _, instance := range slices.Sorted(maps.Keys(nodes)) {
+			if found[instance] {
+				continue
+			}
+			sw, ok := treeRoot.Vertices[topology.NoTopology]
+			if !ok {
+				sw = &topology.Vertex{ID: topology.NoTopology, Vertices: make(map[string]*topology.Vertex)}
+				treeRoot.Vertices[topology.NoTopology] = sw
+			}
+			sw.Vertices[instance] = &topology.Vertex{ID: instance, Name: nodes[instance]}
+		}
+		ret.Vertices[topology.TopologyTree] = treeRoot
+	}
+
+	if blocks, ok := root.Vertices[topology.TopologyBlock]; ok {
+		blockRoot := prune(blocks)
+		if blockRoot == nil {
+			blockRoot = &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		}
+		ret.Vertices[topology.TopologyBlock] = blockRoot
+	}
+
+	return ret
+}
+
+// withProvider returns a copy of the root vertex with the provider recorded in the metadata,
+// and the warnings preceding the warnings of the root vertex
+func withProvider(root *topology.Vertex, name string, warnings []topology.Warning) *topology.Vertex {
+	ret := root.Copy()
+	ret.Warnings = append(slices.Clone(warnings), ret.Warnings...)
+	if ret.Metadata == nil {
+		ret.Metadata = make(map[string]string)
+	}
+	ret.Metadata[topology.KeyProvider] = name
+
+	return ret
+}
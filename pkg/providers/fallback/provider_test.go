@@ -0,0 +1,306 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fallback
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/internal/component"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+type staticProvider struct {
+	root *topology.Vertex
+	err  *httperr.Error
+	// i2n is the node/instance mapping; nil fails the mapping
+	i2n map[string]string
+}
+
+func (p *staticProvider) GenerateTopologyConfig(_ context.Context, _ *int, _ []topology.ComputeInstances) (*topology.Vertex, *httperr.Error) {
+	return p.root, p.err
+}
+
+func (p *staticProvider) Instances2NodeMap(_ context.Context, _ []string) (map[string]string, error) {
+	if p.i2n == nil {
+		return nil, fmt.Errorf("mapping failed")
+	}
+	return p.i2n, nil
+}
+
+func (p *staticProvider) GetInstancesRegions(_ context.Context, _ []string) (map[string]string, error) {
+	return nil, nil
+}
+
+func testRoot(id string) *topology.Vertex {
+	return &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					id: {ID: id, Vertices: map[string]*topology.Vertex{"i1": {ID: "i1", Name: "n1"}}},
+				},
+			},
+		},
+		Metadata: map[string]string{topology.KeyGeneratedAt: "2026-01-01T00:00:00Z"},
+	}
+}
+
+func testRegistry(prvs map[string]*staticProvider) providers.Registry {
+	reg := providers.NewRegistry()
+	for name, prv := range prvs {
+		reg.Register(component.Named(name, func(_ context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+			if _, ok := cfg.Params["fail"]; ok {
+				return nil, httperr.NewError(http.StatusBadRequest, "missing credentials")
+			}
+			return prv, nil
+		}))
+	}
+	return reg
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		ret    *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: missing providers",
+			params: map[string]any{},
+			err:    "missing providers parameter",
+		},
+		{
+			name: "Case 2: missing provider name",
+			params: map[string]any{
+				"providers": []any{map[string]any{}},
+			},
+			err: "missing provider name",
+		},
+		{
+			name: "Case 3: duplicate provider",
+			params: map[string]any{
+				"providers": []any{map[string]any{"name": "oci"}, map[string]any{"name": "oci"}},
+			},
+			err: "duplicate provider oci",
+		},
+		{
+			name: "Case 4: nested fallback provider",
+			params: map[string]any{
+				"providers": []any{map[string]any{"name": "fallback"}},
+			},
+			err: "nested fallback provider is not supported",
+		},
+		{
+			name: "Case 5: valid input",
+			params: map[string]any{
+				"providers": []any{
+					map[string]any{"name": "oci", "params": map[string]any{"a": "b"}},
+					map[string]any{"name": "oci-imds"},
+				},
+				"lastKnown": true,
+			},
+			ret: &Params{
+				Providers: []SubProvider{
+					{Name: "oci", Params: map[string]any{"a": "b"}},
+					{Name: "oci-imds"},
+				},
+				LastKnown: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.ret, p)
+			}
+		})
+	}
+}
+
+func TestGenerateTopologyConfig(t *testing.T) {
+	ctx := context.TODO()
+	api := &staticProvider{root: testRoot("api")}
+	imds := &staticProvider{root: testRoot("imds"), i2n: map[string]string{"i1": "n1"}}
+	reg := testRegistry(map[string]*staticProvider{"api": api, "imds": imds})
+
+	load := func(params map[string]any) *Provider {
+		prv, err := Loader(reg)(ctx, providers.Config{Params: params})
+		require.Nil(t, err)
+		return prv.(*Provider)
+	}
+
+	// Case 1: unknown provider
+	_, err := Loader(reg)(ctx, providers.Config{Params: map[string]any{
+		"providers": []any{map[string]any{"name": "aws"}},
+	}})
+	require.Equal(t, httperr.NewError(http.StatusBadRequest, `unsupported provider "aws"`), err)
+
+	// Case 2: the first provider succeeds
+	prv := load(map[string]any{
+		"providers": []any{map[string]any{"name": "api"}, map[string]any{"name": "imds"}},
+	})
+	root, err := prv.GenerateTopologyConfig(ctx, nil, nil)
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{
+		topology.KeyGeneratedAt: "2026-01-01T00:00:00Z",
+		topology.KeyProvider:    "api",
+	}, root.Metadata)
+	require.Equal(t, api.root.Vertices, root.Vertices)
+	require.NotContains(t, api.root.Metadata, topology.KeyProvider)
+
+	// Case 3: the first provider fails with a server error
+	api.err = httperr.NewError(http.StatusBadGateway, "API error")
+	root, err = prv.GenerateTopologyConfig(ctx, nil, nil)
+	require.Nil(t, err)
+	require.Equal(t, "imds", root.Metadata[topology.KeyProvider])
+	require.Equal(t, imds.root.Vertices, root.Vertices)
+	require.Equal(t, []topology.Warning{
+		{Kind: topology.WarningProvider, Message: "provider api failed: API error"},
+	}, root.Warnings)
+
+	// Case 4: the first provider fails with a client error
+	api.err = httperr.NewError(http.StatusBadRequest, "invalid region")
+	_, err = prv.GenerateTopologyConfig(ctx, nil, nil)
+	require.Equal(t, httperr.NewError(http.StatusBadRequest, "provider api: invalid region"), err)
+
+	// Case 4.1: the first provider fails with an authorization error
+	api.err = httperr.NewError(http.StatusUnauthorized, "expired credentials")
+	root, err = prv.GenerateTopologyConfig(ctx, nil, nil)
+	require.Nil(t, err)
+	require.Equal(t, "imds", root.Metadata[topology.KeyProvider])
+	require.Equal(t, []topology.Warning{
+		{Kind: topology.WarningProvider, Message: "provider api failed: expired credentials"},
+	}, root.Warnings)
+
+	// Case 5: the first provider fails to load
+	api.err = nil
+	prv = load(map[string]any{
+		"providers": []any{map[string]any{"name": "api", "params": map[string]any{"fail": true}}, map[string]any{"name": "imds"}},
+	})
+	root, err = prv.GenerateTopologyConfig(ctx, nil, nil)
+	require.Nil(t, err)
+	require.Equal(t, "imds", root.Metadata[topology.KeyProvider])
+
+	// Case 6: all providers fail
+	imds.err = httperr.NewError(http.StatusInternalServerError, "IMDS error")
+	_, err = prv.GenerateTopologyConfig(ctx, nil, nil)
+	require.Equal(t, httperr.NewError(http.StatusInternalServerError,
+		"all providers failed: api: missing credentials; imds: IMDS error"), err)
+
+	// Case 7: all providers fail and the last known topology is returned
+	imds.err = nil
+	params := map[string]any{
+		"providers": []any{map[string]any{"name": "imds"}},
+		"lastKnown": true,
+	}
+	cis := []topology.ComputeInstances{{Region: "region", Instances: map[string]string{"i1": "n1"}}}
+	root, err = load(params).GenerateTopologyConfig(ctx, nil, cis)
+	require.Nil(t, err)
+	// the engines modify the returned topology
+	root.Vertices[topology.TopologyTree].Vertices["imds"].Vertices["i2"] = &topology.Vertex{ID: "i2", Name: "n2"}
+
+	imds.err = httperr.NewError(http.StatusInternalServerError, "IMDS error")
+	root, err = load(params).GenerateTopologyConfig(ctx, nil, cis)
+	require.Nil(t, err)
+	require.Equal(t, LastKnown, root.Metadata[topology.KeyProvider])
+	require.Equal(t, testRoot("imds").Vertices, root.Vertices)
+
+	// Case 8: the requested instances missing in the last known topology have no topology
+	other := []topology.ComputeInstances{{Region: "region", Instances: map[string]string{"i1": "n1", "i2": "n2"}}}
+	root, err = load(params).GenerateTopologyConfig(ctx, nil, other)
+	require.Nil(t, err)
+	require.Equal(t, map[string]*topology.Vertex{
+		topology.TopologyTree: {
+			Vertices: map[string]*topology.Vertex{
+				"imds": {ID: "imds", Vertices: map[string]*topology.Vertex{"i1": {ID: "i1", Name: "n1"}}},
+				topology.NoTopology: {ID: topology.NoTopology, Vertices: map[string]*topology.Vertex{"i2": {ID: "i2", Name: "n2"}}},
+			},
+		},
+	}, root.Vertices)
+
+	// the instances not requested are left out
+	other = []topology.ComputeInstances{{Region: "region", Instances: map[string]string{"i2": "n2"}}}
+	root, err = load(params).GenerateTopologyConfig(ctx, nil, other)
+	require.Nil(t, err)
+	require.Equal(t, map[string]*topology.Vertex{
+		topology.TopologyTree: {
+			Vertices: map[string]*topology.Vertex{
+				topology.NoTopology: {ID: topology.NoTopology, Vertices: map[string]*topology.Vertex{"i2": {ID: "i2", Name: "n2"}}},
+			},
+		},
+	}, root.Vertices)
+
+	// Case 9: the last known topology is not returned for other provider parameters
+	_, err = load(map[string]any{
+		"providers": []any{map[string]any{"name": "imds", "params": map[string]any{"region": "other"}}},
+		"lastKnown": true,
+	}).GenerateTopologyConfig(ctx, nil, cis)
+	require.Equal(t, httperr.NewError(http.StatusInternalServerError,
+		"all providers failed: imds: IMDS error"), err)
+}
+
+func TestInstances2NodeMap(t *testing.T) {
+	ctx := context.TODO()
+	prv := &Provider{candidates: []*candidate{
+		{name: "aws", err: httperr.NewError(http.StatusBadRequest, "missing credentials")},
+		{name: "api", provider: &staticProvider{}},
+		{name: "imds", provider: &staticProvider{i2n: map[string]string{"i1": "n1"}}},
+	}}
+
+	i2n, err := prv.Instances2NodeMap(ctx, []string{"n1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"i1": "n1"}, i2n)
+
+	prv.candidates = prv.candidates[:2]
+	_, err = prv.Instances2NodeMap(ctx, []string{"n1"})
+	require.EqualError(t, err, "mapping failed")
+
+	prv.candidates = prv.candidates[:1]
+	_, err = prv.Instances2NodeMap(ctx, []string{"n1"})
+	require.EqualError(t, err, "none of the fallback providers maps nodes to instances")
+}
+
+func TestSubset(t *testing.T) {
+	root := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"b1": {ID: "b1", Name: "nvl1", Vertices: map[string]*topology.Vertex{
+						"i1": {ID: "i1", Name: "n1"}, "i2": {ID: "i2", Name: "n2"},
+					}},
+					"b2": {ID: "b2", Name: "nvl2", Vertices: map[string]*topology.Vertex{
+						"i3": {ID: "i3", Name: "n3"},
+					}},
+				},
+			},
+		},
+		Metadata: map[string]string{topology.KeyGeneratedAt: "2026-01-01T00:00:00Z"},
+	}
+
+	cis := []topology.ComputeInstances{{Region: "region", Instances: map[string]string{"i1": "n1", "i4": "n4"}}}
+	require.Equal(t, &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"b1": {ID: "b1", Name: "nvl1", Vertices: map[string]*topology.Vertex{"i1": {ID: "i1", Name: "n1"}}},
+				},
+			},
+		},
+		Metadata: map[string]string{topology.KeyGeneratedAt: "2026-01-01T00:00:00Z"},
+	}, subset(root, cis))
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
@@ -42,15 +43,9 @@ func (p *baseProvider) generateInstanceTopology(ctx context.Context, pageSize *i
 		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to get project ID: %v", err))
 	}
 
-	topo := topology.NewClusterTopology()
-
-	for _, ci := range cis {
-		if httpErr := p.generateRegionInstanceTopology(ctx, client, projectID, topo, &ci); httpErr != nil {
-			return nil, httpErr
-		}
-	}
-
-	return topo, nil
+	return providers.GenerateInstanceTopology(ctx, cis, func(ctx context.Context, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error {
+		return p.generateRegionInstanceTopology(ctx, client, projectID, topo, ci)
+	})
 }
 
 func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, client Client, projectID string, topo *topology.ClusterTopology, ci *topology.ComputeInstances) *httperr.Error {
@@ -80,15 +75,15 @@ func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, clien
 			instanceId := strconv.FormatUint(*instance.Id, 10)
 			klog.V(4).Infof("Checking instance %s", instanceId)
 
-			if _, ok := ci.Instances[instanceId]; ok {
+			if node, ok := ci.Instances[instanceId]; ok {
 				if instance.ResourceStatus == nil {
-					klog.InfoS("ResourceStatus is not set", "instance", instanceId)
+					topo.AddWarning(topology.MissingTopologyWarning(ci.Region, node, "ResourceStatus"))
 					missingResourceStatus.WithLabelValues(instanceId).Inc()
 					continue
 				}
 
 				if instance.ResourceStatus.PhysicalHostTopology == nil {
-					klog.InfoS("PhysicalHostTopology is not set", "instance", instanceId)
+					topo.AddWarning(topology.MissingTopologyWarning(ci.Region, node, "PhysicalHostTopology"))
 					missingPhysicalHostTopology.WithLabelValues(instanceId).Inc()
 					continue
 				}
@@ -96,7 +91,7 @@ func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, clien
 				if instance.ResourceStatus.PhysicalHostTopology.Cluster == nil ||
 					instance.ResourceStatus.PhysicalHostTopology.Block == nil ||
 					instance.ResourceStatus.PhysicalHostTopology.Subblock == nil {
-					klog.InfoS("Missing topology info", "instance", instanceId)
+					topo.AddWarning(topology.MissingTopologyWarning(ci.Region, node, "PhysicalHostTopology cluster, block or subblock"))
 					missingTopologyInfo.WithLabelValues(instanceId).Inc()
 					continue
 				}
@@ -110,12 +110,12 @@ func New(clientFactory ClientFactory) *Provider {
 
 // Engine support
 
-// Instances2NodeMap implements slurm.instanceMapper
+// Instances2NodeMap implements engines.instanceMapper
 func (p *Provider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
 	return instanceToNodeMap(ctx, nodes)
 }
 
-// GetInstancesRegions implements slurm.instanceMapper
+// GetInstancesRegions implements engines.instanceMapper
 func (p *Provider) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
 	return getRegions(ctx, nodes)
 }
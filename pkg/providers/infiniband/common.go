@@ -10,10 +10,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
+	"golang.org/x/sync/errgroup"
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/pkg/ib"
+	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
@@ -25,38 +28,91 @@ type IBNetDiscover interface {
 	Run(context.Context, string) (*bytes.Buffer, error)
 }
 
+// getIbTree runs ibnetdiscover on the nodes, skipping the nodes discovered by a previous run.
+// Since each run discovers the whole fabric of the node, the nodes are run one at a time
+// until the first fabric is discovered; the nodes left undiscovered, in practice on separate fabrics,
+// are then run concurrently, bounded by providers.MaxConcurrency.
+// The switch trees are merged in the order of the nodes.
 func getIbTree(ctx context.Context, cis []topology.ComputeInstances, ibnetdiscover IBNetDiscover) (*topology.Vertex, error) {
+	nodes := topology.GetNodeNameList(cis)
+	results := make([][]*topology.Vertex, len(nodes))
+
+	var mu sync.Mutex
 	nodeVisited := make(map[string]bool)
-	rootMap := make(map[string]*topology.Vertex)
+	visited := func(node string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return nodeVisited[node]
+	}
+
+	discover := func(ctx context.Context, i int) error {
+		node := nodes[i]
+		stdout, err := ibnetdiscover.Run(ctx, node)
+		if err != nil {
+			klog.Warningf("failed to run ibnetdiscover: %v", err)
+			return nil
+		}
+		if !strings.Contains(stdout.String(), "Topology file:") {
+			klog.Warningf("Missing ibnetdiscover output for node %q", node)
+			return nil
+		}
+		ibRoots, hca, err := ib.GenerateTopologyConfig(stdout.Bytes(), cis)
+		if err != nil {
+			return fmt.Errorf("IB GenerateTopologyConfig failed: %v", err)
+		}
+		// mark the visited nodes
+		mu.Lock()
+		for _, nodeName := range hca {
+			nodeVisited[nodeName] = true
+		}
+		mu.Unlock()
+		results[i] = ibRoots
+		return nil
+	}
 
-	for _, node := range topology.GetNodeNameList(cis) {
-		if _, exists := nodeVisited[node]; !exists {
-			stdout, err := ibnetdiscover.Run(ctx, node)
-			if err != nil {
-				klog.Warningf("failed to run ibnetdiscover: %v", err)
-				continue
+	next := 0
+	for ; next < len(nodes); next++ {
+		if err := discover(ctx, next); err != nil {
+			return nil, err
+		}
+		if results[next] != nil {
+			next++
+			break
+		}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(providers.MaxConcurrency(ctx))
+	for i := next; i < len(nodes); i++ {
+		if visited(nodes[i]) {
+			continue
+		}
+		g.Go(func() error {
+			// the node might be discovered while waiting for the run
+			if visited(nodes[i]) {
+				return nil
 			}
-			if strings.Contains(stdout.String(), "Topology file:") {
-				ibRoots, hca, err := ib.GenerateTopologyConfig(stdout.Bytes(), cis)
-				if err != nil {
-					return nil, fmt.Errorf("IB GenerateTopologyConfig failed: %v", err)
-				}
-				// mark the visited nodes
-				for _, nodeName := range hca {
-					nodeVisited[nodeName] = true
-				}
-				for _, v := range ibRoots {
-					rootMap[v.ID] = v
-				}
-			} else {
-				klog.Warningf("Missing ibnetdiscover output for node %q", node)
+			return discover(gctx, i)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	rootMap := make(map[string]*topology.Vertex)
+	ids := []string{}
+	for _, ibRoots := range results {
+		for _, v := range ibRoots {
+			if _, ok := rootMap[v.ID]; !ok {
+				ids = append(ids, v.ID)
 			}
+			rootMap[v.ID] = v
 		}
 	}
 
 	roots := make([]*topology.Vertex, 0, len(rootMap))
-	for _, v := range rootMap {
-		roots = append(roots, v)
+	for _, id := range ids {
+		roots = append(roots, rootMap[id])
 	}
 
 	merger := topology.NewMerger(roots)
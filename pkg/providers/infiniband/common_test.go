@@ -10,18 +10,23 @@ import (
 	"context"
 	"errors"
 	"os"
+	"sync/atomic"
 	"testing"
 
+	"github.com/agrea/ptr"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
 type testIBNetDiscover struct {
-	err bool
+	err  bool
+	runs atomic.Int32
 }
 
 func (h *testIBNetDiscover) Run(ctx context.Context, node string) (*bytes.Buffer, error) {
+	h.runs.Add(1)
 	if h.err {
 		return nil, errors.New("error")
 	}
@@ -104,3 +109,30 @@ func TestGetIbTree(t *testing.T) {
 		})
 	}
 }
+
+func TestGetIbTreeSkipsDiscoveredNodes(t *testing.T) {
+	ctx := providers.WithMaxConcurrency(context.TODO(), ptr.Int(4))
+	cis := []topology.ComputeInstances{
+		{
+			Region: "on-prem",
+			Instances: map[string]string{
+				"b07-p1-dgx-07-c01": "b07-p1-dgx-07-c01",
+				"b07-p1-dgx-07-c02": "b07-p1-dgx-07-c02",
+				"b07-p1-dgx-07-c03": "b07-p1-dgx-07-c03",
+				"b07-p1-dgx-07-c04": "b07-p1-dgx-07-c04",
+			},
+		},
+	}
+
+	// the first run discovers all nodes of the fabric, so it is not scanned concurrently
+	ibnetdiscover := &testIBNetDiscover{}
+	_, err := getIbTree(ctx, cis, ibnetdiscover)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), ibnetdiscover.runs.Load())
+
+	// the nodes are run in turn until a fabric is discovered
+	ibnetdiscover = &testIBNetDiscover{err: true}
+	_, err = getIbTree(ctx, cis, ibnetdiscover)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), ibnetdiscover.runs.Load())
+}
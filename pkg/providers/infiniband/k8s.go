@@ -9,6 +9,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -102,6 +103,80 @@ func parseClusterID(txt string) (string, error) {
 	return clusterUUID + "." + cliqueId, nil
 }
 
+// ErrNVLinkDomainsUnavailable is returned by the NVLink domain lookup when topograph does not run in Kubernetes
+var ErrNVLinkDomainsUnavailable = errors.New("not running in Kubernetes")
+
+// GetInClusterClient returns the client of the Kubernetes cluster running topograph,
+// or nil if topograph does not run in Kubernetes
+func GetInClusterClient() (*kubernetes.Clientset, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		if errors.Is(err, rest.ErrNotInCluster) {
+			klog.Info("Not running in Kubernetes; NVLink domains are not available")
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(cfg)
+}
+
+// GetNVLinkDomains maps the instance annotation of the nodes to their cluster ID annotation,
+// written by node-data-broker-initc from the nvidia-smi output
+func GetNVLinkDomains(ctx context.Context, client *kubernetes.Clientset) (map[string]string, error) {
+	nodes, err := k8s.GetNodes(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := make(map[string]string)
+	for _, node := range nodes.Items {
+		instance, ok := node.Annotations[topology.KeyNodeInstance]
+		if !ok {
+			continue
+		}
+		if clusterID, ok := node.Annotations[topology.KeyNodeClusterID]; ok {
+			domains[instance] = clusterID
+		}
+	}
+
+	return domains, nil
+}
+
+// NVLinkAnnotations provides the NVLink domains of the node annotations written by node-data-broker-initc
+// to the providers whose APIs do not expose the NVLink domains of the instances
+type NVLinkAnnotations struct {
+	// Client is the in-cluster client; nil if topograph does not run in Kubernetes
+	Client *kubernetes.Clientset
+	// InstanceID (optional) converts the instance annotation into the instance ID of the provider
+	InstanceID func(string) string
+}
+
+// NVLinkDomains maps the instance IDs to their NVLink domains
+func (a *NVLinkAnnotations) NVLinkDomains(ctx context.Context) (map[string]string, error) {
+	if a.Client == nil {
+		return nil, ErrNVLinkDomainsUnavailable
+	}
+
+	domains, err := GetNVLinkDomains(ctx, a.Client)
+	if err != nil || a.InstanceID == nil {
+		return domains, err
+	}
+
+	ret := make(map[string]string, len(domains))
+	for instance, domain := range domains {
+		ret[a.InstanceID(instance)] = domain
+	}
+
+	return ret, nil
+}
+
+// IsValidClusterID checks that the cluster ID consists of the ClusterUUID and the CliqueId
+func IsValidClusterID(clusterID string) bool {
+	clusterUUID, cliqueID, ok := strings.Cut(clusterID, ".")
+	return ok && len(clusterUUID) != 0 && len(cliqueID) != 0
+}
+
 func GetNodeAnnotations(ctx context.Context, client *kubernetes.Clientset, config *rest.Config, hostname string) (map[string]string, error) {
 	annotations := map[string]string{
 		topology.KeyNodeInstance: hostname,
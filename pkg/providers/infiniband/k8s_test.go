@@ -6,6 +6,7 @@
 package infiniband
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -49,3 +50,16 @@ func TestParseClusterID(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidClusterID(t *testing.T) {
+	require.True(t, IsValidClusterID("00000000-0000-0000-0000-000000000000.0"))
+	require.False(t, IsValidClusterID("00000000-0000-0000-0000-000000000000"))
+	require.False(t, IsValidClusterID("00000000-0000-0000-0000-000000000000."))
+	require.False(t, IsValidClusterID(".0"))
+}
+
+func TestNVLinkAnnotations(t *testing.T) {
+	annotations := &NVLinkAnnotations{}
+	_, err := annotations.NVLinkDomains(context.TODO())
+	require.ErrorIs(t, err, ErrNVLinkDomainsUnavailable)
+}
@@ -53,7 +53,7 @@ func (p *ProviderBM) GenerateTopologyConfig(ctx context.Context, _ *int, cis []t
 	return toGraph(domainMap, treeRoot), nil
 }
 
-// Instances2NodeMap implements slurm.instanceMapper
+// Instances2NodeMap implements engines.instanceMapper
 func (p *ProviderBM) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
 	i2n := make(map[string]string)
 	for _, node := range nodes {
@@ -63,7 +63,7 @@ func (p *ProviderBM) Instances2NodeMap(ctx context.Context, nodes []string) (map
 	return i2n, nil
 }
 
-// GetInstancesRegions implements slurm.instanceMapper
+// GetInstancesRegions implements engines.instanceMapper
 func (p *ProviderBM) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
 	res := make(map[string]string)
 	for _, node := range nodes {
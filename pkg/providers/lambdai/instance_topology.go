@@ -13,6 +13,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
@@ -22,65 +23,80 @@ func (p *baseProvider) generateInstanceTopology(ctx context.Context, pageSize *i
 		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to create API client: %v", err))
 	}
 
-	topo := topology.NewClusterTopology()
+	// the instance list covers the whole workspace: list it once and split it by the regions of the request
+	var instances []InstanceTopology
+	if len(cis) != 0 {
+		if instances, err = listInstances(ctx, client); err != nil {
+			return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to get instance list: %v", err))
+		}
+	}
+
+	return providers.GenerateInstanceTopology(ctx, cis, func(_ context.Context, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error {
+		return p.generateRegionInstanceTopology(instances, topo, ci)
+	})
+}
 
-	for _, ci := range cis {
-		if err := p.generateRegionInstanceTopology(ctx, client, topo, &ci); err != nil {
+func listInstances(ctx context.Context, client Client) ([]InstanceTopology, error) {
+	var instances []InstanceTopology
+	req := &InstanceListRequest{PageSize: client.PageSize()}
+
+	for {
+		resp, err := client.InstanceList(ctx, req)
+		if err != nil {
 			return nil, err
 		}
-	}
+		instances = append(instances, resp.Items...)
 
-	return topo, nil
+		if len(resp.NextPageToken) == 0 {
+			klog.V(4).Infof("Total listed instances: %d", len(instances))
+			return instances, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
 }
 
-func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, client Client, topo *topology.ClusterTopology, ci *topology.ComputeInstances) *httperr.Error {
+func (p *baseProvider) generateRegionInstanceTopology(instances []InstanceTopology, topo *topology.ClusterTopology, ci *topology.ComputeInstances) *httperr.Error {
 	if len(ci.Region) == 0 {
 		return httperr.NewError(http.StatusBadRequest, "must specify region")
 	}
 	klog.InfoS("Getting instance topology", "region", ci.Region)
 
-	req := &InstanceListRequest{PageSize: client.PageSize()}
-
-	for {
-		resp, err := client.InstanceList(ctx, req)
-		if err != nil {
-			return httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to get instance list: %v", err))
+	for _, inst := range instances {
+		node, ok := ci.Instances[inst.ID]
+		if !ok {
+			continue
 		}
 
-		for _, inst := range resp.Items {
-			t := &topology.InstanceTopology{
-				InstanceID: inst.ID,
-			}
+		t := &topology.InstanceTopology{
+			InstanceID: inst.ID,
+		}
 
-			for indx := range len(inst.NetworkPath) {
-				switch indx {
-				case 0:
-					t.BlockID = inst.NetworkPath[indx]
-				case 1:
-					t.SpineID = inst.NetworkPath[indx]
-				case 2:
-					t.DatacenterID = inst.NetworkPath[indx]
-				default:
-					klog.Warningf("unsupported size %d of topology path for instance %q", len(inst.NetworkPath), inst.ID)
-				}
+		for indx := range len(inst.NetworkPath) {
+			switch indx {
+			case 0:
+				t.BlockID = inst.NetworkPath[indx]
+			case 1:
+				t.SpineID = inst.NetworkPath[indx]
+			case 2:
+				t.DatacenterID = inst.NetworkPath[indx]
+			default:
+				klog.Warningf("unsupported size %d of topology path for instance %q", len(inst.NetworkPath), inst.ID)
 			}
+		}
 
-			if inst.NVLink != nil {
-				if len(inst.NVLink.DomainID) == 0 || len(inst.NVLink.CliqueID) == 0 {
-					klog.Warningf("incomplete NVL data for instance %s: DomainID=%q CliqueID=%q", inst.ID, inst.NVLink.DomainID, inst.NVLink.CliqueID)
-				} else {
-					t.AcceleratorID = inst.NVLink.DomainID + "." + inst.NVLink.CliqueID
-				}
+		if inst.NVLink != nil {
+			if len(inst.NVLink.DomainID) == 0 || len(inst.NVLink.CliqueID) == 0 {
+				detail := fmt.Sprintf("DomainID=%q CliqueID=%q", inst.NVLink.DomainID, inst.NVLink.CliqueID)
+				topo.AddWarning(topology.IncompleteNVLinkWarning(ci.Region, node, detail))
+			} else {
+				t.AcceleratorID = inst.NVLink.DomainID + "." + inst.NVLink.CliqueID
 			}
-
-			klog.Infof("Adding topology: %s", t.String())
-			topo.Append(t)
 		}
 
-		if len(resp.NextPageToken) == 0 {
-			klog.V(4).Infof("Total processed nodes: %d", topo.Len())
-			return nil
-		}
-		req.PageToken = resp.NextPageToken
+		klog.Infof("Adding topology: %s", t.String())
+		topo.Append(t)
 	}
+
+	klog.V(4).Infof("Total processed nodes: %d", topo.Len())
+	return nil
 }
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package lambdai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/models"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME_SIM = "lambdai-sim"
+
+	errNone = iota
+	errClientFactory
+	errInstanceList
+)
+
+// simClient lists the nodes of the model as the instances of the workspace.
+// The network path is the switch path of the node, from the leaf switch up,
+// and the NVLink clique is the capacity block of the node.
+type simClient struct {
+	model       *models.Model
+	pageSize    int
+	instanceIDs []string
+	apiErr      int
+}
+
+func (c *simClient) WorkspaceID() string {
+	return "simulation"
+}
+
+func (c *simClient) PageSize() int {
+	return c.pageSize
+}
+
+func (c *simClient) InstanceList(_ context.Context, req *InstanceListRequest) (*InstanceListResponse, error) {
+	if c.apiErr == errInstanceList {
+		return nil, providers.ErrAPIError
+	}
+
+	resp := &InstanceListResponse{Items: []InstanceTopology{}}
+
+	var indx int
+	from := getPage(req.PageToken)
+	for indx = from; indx < from+req.PageSize && indx < len(c.instanceIDs); indx++ {
+		node := c.model.Nodes[c.instanceIDs[indx]]
+		inst := InstanceTopology{
+			ID:          node.Name,
+			NetworkPath: slices.Clone(node.NetLayers),
+		}
+		if len(node.NVLink) != 0 {
+			inst.NVLink = &NVLinkInfo{
+				DomainID: node.NVLink,
+				CliqueID: node.CapacityBlock,
+			}
+		}
+		resp.Items = append(resp.Items, inst)
+	}
+
+	if indx < len(c.instanceIDs) {
+		resp.NextPageToken = strconv.Itoa(indx)
+	}
+
+	return resp, nil
+}
+
+func getPage(page string) int {
+	val, _ := strconv.ParseInt(page, 10, 32)
+	return int(val)
+}
+
+func NamedLoaderSim() (string, providers.Loader) {
+	return NAME_SIM, LoaderSim
+}
+
+func LoaderSim(_ context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+	p, err := providers.GetSimulationParams(cfg.Params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	model, err := models.NewModelFromFile(p.ModelPath)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, fmt.Sprintf("failed to load model file: %v", err))
+	}
+
+	instanceIDs := make([]string, 0, len(model.Nodes))
+	for _, node := range model.Nodes {
+		instanceIDs = append(instanceIDs, node.Name)
+	}
+	slices.Sort(instanceIDs)
+
+	clientFactory := func(pageSize *int) (Client, error) {
+		if p.APIError == errClientFactory {
+			return nil, providers.ErrAPIError
+		}
+
+		limit := getPageSize(pageSize)
+		if limit <= 0 {
+			limit = defaultPageSize
+		}
+
+		return &simClient{
+			model:       model,
+			pageSize:    limit,
+			instanceIDs: instanceIDs,
+			apiErr:      p.APIError,
+		}, nil
+	}
+
+	return NewSim(clientFactory), nil
+}
+
+type simProvider struct {
+	baseProvider
+}
+
+func NewSim(clientFactory ClientFactory) *simProvider {
+	return &simProvider{
+		baseProvider: baseProvider{clientFactory: clientFactory},
+	}
+}
+
+// Engine support
+
+func (p *simProvider) GetComputeInstances(ctx context.Context) ([]topology.ComputeInstances, *httperr.Error) {
+	client, _ := p.clientFactory(nil)
+
+	return client.(*simClient).model.Instances, nil
+}
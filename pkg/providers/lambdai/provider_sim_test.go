@@ -0,0 +1,301 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package lambdai
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/agrea/ptr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/engines/slurm"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	ignoreErrMsg = "_IGNORE_"
+
+	nodeModel = `
+switches:
+- name: core
+  switches: [spine]
+- name: spine
+  switches: [tor]
+- name: tor
+  capacity_blocks: [cb]
+capacity_blocks:
+- name: cb
+  type: GB200
+  nvlink: nvl1
+  nodes: [11]
+`
+
+	clusterModel = `
+switches:
+- name: core
+  switches: [spine]
+- name: spine
+  switches: [tor1,tor2]
+- name: tor1
+  capacity_blocks: [cb1]
+- name: tor2
+  capacity_blocks: [cb2]
+capacity_blocks:
+- name: cb1
+  type: GB200
+  nvlink: nvl1
+  nodes: [11,12]
+- name: cb2
+  type: GB200
+  nvlink: nvl2
+  nodes: [21,22]
+`
+)
+
+func TestProviderSim(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		model     string
+		pageSize  *int
+		instances []topology.ComputeInstances
+		params    map[string]any
+		apiErr    int
+		topology  string
+		err       string
+	}{
+		{
+			name:  "Case 1: bad model",
+			model: `bad: model: error:`,
+			err:   ignoreErrMsg,
+		},
+		{
+			name:  "Case 2: no ComputeInstances",
+			model: clusterModel,
+		},
+		{
+			name:  "Case 3.1: ClientFactory API error",
+			model: nodeModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11"},
+				},
+			},
+			apiErr: errClientFactory,
+			err:    "failed to create API client: API error",
+		},
+		{
+			name:  "Case 3.2: InstanceList API error",
+			model: nodeModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11"},
+				},
+			},
+			apiErr: errInstanceList,
+			err:    "failed to get instance list: API error",
+		},
+		{
+			name:  "Case 4: missing region",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Instances: map[string]string{"11": "node11", "12": "nodeCPU"},
+				},
+			},
+			err: "must specify region",
+		},
+		{
+			name:  "Case 5: valid single node",
+			model: nodeModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "12": "nodeCPU"},
+				},
+			},
+			topology: `SwitchName=core Switches=spine
+SwitchName=no-topology Nodes=nodeCPU
+SwitchName=spine Switches=tor
+SwitchName=tor Nodes=node11
+`,
+		},
+		{
+			name:  "Case 6: valid cluster, no pagination",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21", "22": "node22"},
+				},
+			},
+			topology: `SwitchName=core Switches=spine
+SwitchName=spine Switches=tor[1-2]
+SwitchName=tor1 Nodes=node[11-12]
+SwitchName=tor2 Nodes=node[21-22]
+`,
+		},
+		{
+			name:     "Case 7: valid cluster, pagination",
+			model:    clusterModel,
+			pageSize: ptr.Int(3),
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21", "22": "node22", "31": "node31"},
+				},
+			},
+			topology: `SwitchName=core Switches=spine
+SwitchName=no-topology Nodes=node31
+SwitchName=spine Switches=tor[1-2]
+SwitchName=tor1 Nodes=node[11-12]
+SwitchName=tor2 Nodes=node[21-22]
+`,
+		},
+		{
+			name:  "Case 8: only the requested instances",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "12": "node12"},
+				},
+			},
+			topology: `SwitchName=core Switches=spine
+SwitchName=spine Switches=tor1
+SwitchName=tor1 Nodes=node[11-12]
+`,
+		},
+		{
+			name:  "Case 9: only the instances of each region",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region1",
+					Instances: map[string]string{"11": "node11", "12": "node12"},
+				},
+				{
+					Region:    "region2",
+					Instances: map[string]string{"21": "node21"},
+				},
+			},
+			topology: `SwitchName=core Switches=spine
+SwitchName=spine Switches=tor[1-2]
+SwitchName=tor1 Nodes=node[11-12]
+SwitchName=tor2 Nodes=node21
+`,
+		},
+		{
+			name:   "Case 10: valid cluster in block format",
+			model:  clusterModel,
+			params: map[string]any{"plugin": "topology/block"},
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21", "22": "node22", "31": "node31"},
+				},
+			},
+			topology: `# block002=nvl2.cb2
+BlockName=block002 Nodes=node[21-22]
+# block001=nvl1.cb1
+BlockName=block001 Nodes=node[11-12]
+BlockSizes=2,4
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.CreateTemp("", "test-*")
+			require.NoError(t, err)
+			defer func() { _ = os.Remove(f.Name()) }()
+			defer func() { _ = f.Close() }()
+			n, err := f.WriteString(tc.model)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.model), n)
+			err = f.Sync()
+			require.NoError(t, err)
+
+			cfg := providers.Config{
+				Params: map[string]any{
+					"model_path": f.Name(),
+					"api_error":  tc.apiErr,
+				},
+			}
+			provider, httpErr := LoaderSim(ctx, cfg)
+			if httpErr != nil {
+				if len(tc.err) == 0 {
+					require.Nil(t, httpErr)
+				} else if tc.err != ignoreErrMsg {
+					require.EqualError(t, httpErr, tc.err)
+				}
+				return
+			}
+
+			topo, httpErr := provider.GenerateTopologyConfig(ctx, tc.pageSize, tc.instances)
+			if len(tc.err) != 0 {
+				require.EqualError(t, httpErr, tc.err)
+			} else {
+				require.Nil(t, httpErr)
+				data, httpErr := slurm.GenerateOutput(ctx, topo, tc.params)
+				require.Nil(t, httpErr)
+				require.Equal(t, tc.topology, string(data))
+			}
+		})
+	}
+}
+
+func TestGenerateInstanceTopology(t *testing.T) {
+	f, err := os.CreateTemp("", "test-*")
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(f.Name()) }()
+	defer func() { _ = f.Close() }()
+	_, err = f.WriteString(clusterModel)
+	require.NoError(t, err)
+
+	provider, httpErr := LoaderSim(context.TODO(), providers.Config{Params: map[string]any{"model_path": f.Name()}})
+	require.Nil(t, httpErr)
+
+	sim := provider.(*simProvider)
+	clientFactory := sim.clientFactory
+	var calls int
+	sim.clientFactory = func(pageSize *int) (Client, error) {
+		client, err := clientFactory(pageSize)
+		return &countingClient{Client: client, calls: &calls}, err
+	}
+
+	// the instance list covers the whole workspace, but only the requested instances are returned
+	cis := []topology.ComputeInstances{
+		{Region: "region1", Instances: map[string]string{"11": "node11"}},
+		{Region: "region2", Instances: map[string]string{"21": "node21", "22": "node22"}},
+	}
+	topo, httpErr := sim.generateInstanceTopology(context.TODO(), ptr.Int(1), cis)
+	require.Nil(t, httpErr)
+	require.Equal(t, []*topology.InstanceTopology{
+		{InstanceID: "11", BlockID: "tor1", SpineID: "spine", DatacenterID: "core", AcceleratorID: "nvl1.cb1"},
+		{InstanceID: "21", BlockID: "tor2", SpineID: "spine", DatacenterID: "core", AcceleratorID: "nvl2.cb2"},
+		{InstanceID: "22", BlockID: "tor2", SpineID: "spine", DatacenterID: "core", AcceleratorID: "nvl2.cb2"},
+	}, topo.Instances)
+
+	// the workspace is listed once, one instance per page, for all the regions
+	require.Equal(t, 4, calls)
+}
+
+type countingClient struct {
+	Client
+	calls *int
+}
+
+func (c *countingClient) InstanceList(ctx context.Context, req *InstanceListRequest) (*InstanceListResponse, error) {
+	*c.calls++
+	return c.Client.InstanceList(ctx, req)
+}
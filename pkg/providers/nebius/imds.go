@@ -10,8 +10,13 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
 	"github.com/NVIDIA/topograph/internal/exec"
 	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
@@ -45,7 +50,7 @@ func getParentID() (string, error) {
 	return providers.ReadFile(IMDSParentID)
 }
 
-func GetNodeAnnotations(ctx context.Context) (map[string]string, error) {
+func GetNodeAnnotations(ctx context.Context, client *kubernetes.Clientset, config *rest.Config, hostname string) (map[string]string, error) {
 	mac, err := exec.Exec(ctx, "sh", []string{"-c", MACCmd}, nil)
 	if err != nil {
 		return nil, err
@@ -56,8 +61,17 @@ func GetNodeAnnotations(ctx context.Context) (map[string]string, error) {
 		return nil, err
 	}
 
-	return map[string]string{
+	annotations := map[string]string{
 		topology.KeyNodeInstance: strings.TrimSpace(mac.String()),
 		topology.KeyNodeRegion:   strings.TrimSpace(region),
-	}, nil
+	}
+
+	// the NVLink domain is reported by nvidia-smi on the GPU nodes
+	if clusterID, err := infiniband.GetClusterID(ctx, client, config, hostname); err != nil {
+		klog.Warningf("No clusterID for node %s: %v", hostname, err)
+	} else if len(clusterID) != 0 {
+		annotations[topology.KeyNodeClusterID] = clusterID
+	}
+
+	return annotations, nil
 }
@@ -7,6 +7,7 @@ package nebius
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -15,6 +16,8 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
@@ -24,18 +27,31 @@ func (p *baseProvider) generateInstanceTopology(ctx context.Context, pageSize *i
 		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to create API client: %v", err))
 	}
 
-	topo := topology.NewClusterTopology()
+	// the tree topology is valid without the NVLink domains under the partial results policy,
+	// or if they are not available outside Kubernetes
+	domains, err := client.NVLinkDomains(ctx)
+	if err != nil && !errors.Is(err, infiniband.ErrNVLinkDomainsUnavailable) && !providers.Tolerable(ctx, http.StatusBadGateway) {
+		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to get NVLink domains: %v", err))
+	}
 
-	for _, ci := range cis {
-		if err := p.generateRegionInstanceTopology(ctx, client, topo, &ci); err != nil {
-			return nil, err
-		}
+	topo, httpErr := providers.GenerateInstanceTopology(ctx, cis, func(ctx context.Context, ci *topology.ComputeInstances, topo *topology.ClusterTopology) *httperr.Error {
+		return p.generateRegionInstanceTopology(ctx, client, domains, topo, ci)
+	})
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	if err != nil {
+		topo.AddWarning(topology.Warning{
+			Kind:    topology.WarningNVLink,
+			Message: fmt.Sprintf("failed to get NVLink domains: %v", err),
+		})
 	}
 
 	return topo, nil
 }
 
-func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, client Client, topo *topology.ClusterTopology, ci *topology.ComputeInstances) *httperr.Error {
+func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, client Client, domains map[string]string, topo *topology.ClusterTopology, ci *topology.ComputeInstances) *httperr.Error {
 	if len(ci.Region) == 0 {
 		return httperr.NewError(http.StatusBadRequest, "must specify region")
 	}
@@ -60,7 +76,7 @@ func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, clien
 			}
 			ibTopology := instance.GetStatus().GetInfinibandTopologyPath()
 			if ibTopology == nil {
-				klog.Warningf("missing topology path for node %q", hostname)
+				topo.AddWarning(topology.MissingTopologyWarning(ci.Region, hostname, "InfinibandTopologyPath"))
 				continue
 			}
 
@@ -75,10 +91,25 @@ func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, clien
 				inst.SpineID = path[1]
 				inst.BlockID = path[2]
 			default:
-				klog.Warningf("unsupported size %d of topology path for node %q", len(path), hostname)
+				topo.AddWarning(topology.Warning{
+					Kind:    topology.WarningTopology,
+					Region:  ci.Region,
+					Node:    hostname,
+					Message: fmt.Sprintf("unsupported size %d of topology path for node %s", len(path), hostname),
+				})
 				continue
 			}
 
+			// the instance annotations hold the upper-case MAC addresses, while the instance ID
+			// keeps the MAC address as reported by the compute API
+			if domain, ok := domains[strings.ToUpper(intf)]; ok {
+				if infiniband.IsValidClusterID(domain) {
+					inst.AcceleratorID = domain
+				} else {
+					topo.AddWarning(topology.IncompleteNVLinkWarning(ci.Region, hostname, fmt.Sprintf("cluster ID %q", domain)))
+				}
+			}
+
 			klog.Infof("Adding topology: %s", inst.String())
 			topo.Append(inst)
 		}
@@ -91,6 +122,7 @@ func (p *baseProvider) generateRegionInstanceTopology(ctx context.Context, clien
 	}
 }
 
+// hasNetIntf returns the node name and the MAC address of the requested instance
 func hasNetIntf(ci *topology.ComputeInstances, nw []*compute.NetworkInterfaceStatus) (string, string, bool) {
 	for _, status := range nw {
 		if hostname, ok := ci.Instances[strings.ToUpper(status.MacAddress)]; ok {
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/nebius/gosdk"
 	"github.com/nebius/gosdk/auth"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
@@ -38,6 +40,8 @@ const (
 type Client interface {
 	ProjectID() string
 	GetComputeInstanceList(context.Context, *compute.ListInstancesRequest) (*compute.ListInstancesResponse, error)
+	// NVLinkDomains returns the <ClusterUUID>.<CliqueId> NVLink domains of the instances
+	NVLinkDomains(context.Context) (map[string]string, error)
 	PageSize() int64
 }
 
@@ -51,6 +55,9 @@ type nebiusClient struct {
 	instanceService services.InstanceService
 	projectID       string
 	pageSize        int
+
+	// the compute API does not expose the NVLink domains of the instances
+	infiniband.NVLinkAnnotations
 }
 
 func (c *nebiusClient) ProjectID() string {
@@ -86,12 +93,22 @@ func Loader(ctx context.Context, config providers.Config) (providers.Provider, *
 	}
 	klog.Infof("Project ID %s", projectID)
 
+	kubeClient, err := infiniband.GetInClusterClient()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, fmt.Sprintf("failed to create kubernetes client: %v", err))
+	}
+
 	instanceService := sdk.Services().Compute().V1().Instance()
 	clientFactory := func(pageSize *int) (Client, error) {
 		return &nebiusClient{
 			instanceService: instanceService,
-			projectID:       projectID,
-			pageSize:        getPageSize(pageSize),
+			NVLinkAnnotations: infiniband.NVLinkAnnotations{
+				Client: kubeClient,
+				// the instance IDs are the upper-case MAC addresses
+				InstanceID: strings.ToUpper,
+			},
+			projectID: projectID,
+			pageSize:  getPageSize(pageSize),
 		}, nil
 	}
 
@@ -177,12 +194,12 @@ func New(clientFactory ClientFactory) *Provider {
 
 // Engine support
 
-// Instances2NodeMap implements slurm.instanceMapper
+// Instances2NodeMap implements engines.instanceMapper
 func (p *Provider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
 	return instanceToNodeMap(ctx, nodes)
 }
 
-// GetInstancesRegions implements slurm.instanceMapper
+// GetInstancesRegions implements engines.instanceMapper
 func (p *Provider) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
 	return getRegions(ctx, nodes)
 }
@@ -10,12 +10,14 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	compute "github.com/nebius/gosdk/proto/nebius/compute/v1"
 
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/pkg/models"
 	"github.com/NVIDIA/topograph/pkg/providers"
+	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
@@ -27,6 +29,8 @@ const (
 	errInstances
 	errTopologyPath
 	errNetworkIntf
+	errNVLinkDomains
+	errNVLinkUnavailable
 )
 
 type simClient struct {
@@ -90,6 +94,32 @@ func (c *simClient) GetComputeInstanceList(ctx context.Context, req *compute.Lis
 	return resp, nil
 }
 
+// NVLinkDomains returns the cluster ID annotations of the nodes of the model, made of
+// the NVLink domain and the clique of their capacity block
+func (c *simClient) NVLinkDomains(ctx context.Context) (map[string]string, error) {
+	switch c.apiErr {
+	case errNVLinkDomains:
+		return nil, providers.ErrAPIError
+	case errNVLinkUnavailable:
+		return nil, infiniband.ErrNVLinkDomainsUnavailable
+	}
+
+	domains := make(map[string]string)
+	for _, node := range c.model.Nodes {
+		instance := strings.ToUpper(node.Name)
+		switch {
+		case len(node.NVLink) == 0:
+			continue
+		case len(node.Clique) == 0:
+			domains[instance] = node.NVLink
+		default:
+			domains[instance] = node.NVLink + "." + node.Clique
+		}
+	}
+
+	return domains, nil
+}
+
 func getStartIndex(token string) int {
 	if len(token) == 0 {
 		return 0
@@ -50,6 +50,30 @@ capacity_blocks:
 - name: cb1
   type: GB200
   nvlink: nvl1
+  clique: "1"
+  nodes: [11,12]
+- name: cb2
+  type: GB200
+  nvlink: nvl2
+  clique: "1"
+  nodes: [21,22]
+`
+
+	incompleteModel = `
+switches:
+- name: core
+  switches: [spine]
+- name: spine
+  switches: [tor1,tor2]
+- name: tor1
+  capacity_blocks: [cb1]
+- name: tor2
+  capacity_blocks: [cb2]
+capacity_blocks:
+- name: cb1
+  type: GB200
+  nvlink: nvl1
+  clique: "1"
   nodes: [11,12]
 - name: cb2
   type: GB200
@@ -68,6 +92,7 @@ func TestProviderSim(t *testing.T) {
 		instances []topology.ComputeInstances
 		params    map[string]any
 		apiErr    int
+		partial   bool
 		topology  string
 		err       string
 	}{
@@ -110,7 +135,8 @@ func TestProviderSim(t *testing.T) {
 				},
 			},
 			apiErr: errTopologyPath,
-			topology: `SwitchName=no-topology Nodes=node11
+			topology: `# warning: unsupported size 0 of topology path for node node11
+SwitchName=no-topology Nodes=node11
 `,
 		},
 		{
@@ -159,6 +185,88 @@ SwitchName=no-topology Nodes=node31
 SwitchName=spine Switches=tor[1-2]
 SwitchName=tor1 Nodes=node[11-12]
 SwitchName=tor2 Nodes=node[21-22]
+`,
+		},
+		{
+			name:   "Case 8: valid cluster in block format",
+			model:  clusterModel,
+			params: map[string]any{"plugin": "topology/block"},
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21", "22": "node22", "31": "node31"},
+				},
+			},
+			topology: `# block002=nvl2.1
+BlockName=block002 Nodes=node[21-22]
+# block001=nvl1.1
+BlockName=block001 Nodes=node[11-12]
+BlockSizes=2,4
+`,
+		},
+		{
+			name:   "Case 9: incomplete NVLink domain",
+			model:  incompleteModel,
+			params: map[string]any{"plugin": "topology/block"},
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "12": "node12", "21": "node21"},
+				},
+			},
+			topology: `# warning: NVL data incomplete for node21: cluster ID "nvl2"
+# block001=nvl1.1
+BlockName=block001 Nodes=node[11-12]
+BlockSizes=2
+`,
+		},
+		{
+			name:  "Case 10: NVLink domains API error",
+			model: clusterModel,
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11"},
+				},
+			},
+			apiErr: errNVLinkDomains,
+			err:    "failed to get NVLink domains: API error",
+		},
+		{
+			name:   "Case 11: NVLink domains API error under the partial results policy",
+			model:  clusterModel,
+			params: map[string]any{"plugin": "topology/tree"},
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "21": "node21"},
+				},
+			},
+			apiErr:  errNVLinkDomains,
+			partial: true,
+			topology: `# warning: failed to get NVLink domains: API error
+SwitchName=core Switches=spine
+SwitchName=spine Switches=tor[1-2]
+SwitchName=tor1 Nodes=node11
+SwitchName=tor2 Nodes=node21
+`,
+		},
+		{
+			name:   "Case 12: NVLink domains unavailable outside Kubernetes",
+			model:  clusterModel,
+			params: map[string]any{"plugin": "topology/tree"},
+			instances: []topology.ComputeInstances{
+				{
+					Region:    "region",
+					Instances: map[string]string{"11": "node11", "21": "node21"},
+				},
+			},
+			apiErr: errNVLinkUnavailable,
+			topology: `# warning: failed to get NVLink domains: not running in Kubernetes
+SwitchName=core Switches=spine
+SwitchName=spine Switches=tor[1-2]
+SwitchName=tor1 Nodes=node11
+SwitchName=tor2 Nodes=node21
 `,
 		},
 	}
@@ -191,6 +299,7 @@ SwitchName=tor2 Nodes=node[21-22]
 				return
 			}
 
+			ctx := providers.WithPartialResults(ctx, tc.partial)
 			topo, httpErr := provider.GenerateTopologyConfig(ctx, tc.pageSize, tc.instances)
 			if len(tc.err) != 0 {
 				require.EqualError(t, httpErr, tc.err)
@@ -9,7 +9,10 @@ import (
 	"os"
 	"testing"
 
+	compute "github.com/nebius/gosdk/proto/nebius/compute/v1"
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
 func TestGetAuthOption(t *testing.T) {
@@ -70,3 +73,17 @@ func TestGetAuthOption(t *testing.T) {
 		})
 	}
 }
+
+func TestHasNetIntf(t *testing.T) {
+	ci := &topology.ComputeInstances{Instances: map[string]string{"AA:BB:CC:DD:EE:FF": "node1"}}
+	nw := []*compute.NetworkInterfaceStatus{{MacAddress: "00:11:22:33:44:55"}, {MacAddress: "aa:bb:cc:dd:ee:ff"}}
+
+	// the instance ID keeps the MAC address reported by the compute API
+	hostname, instance, ok := hasNetIntf(ci, nw)
+	require.True(t, ok)
+	require.Equal(t, "node1", hostname)
+	require.Equal(t, "aa:bb:cc:dd:ee:ff", instance)
+
+	_, _, ok = hasNetIntf(ci, nw[:1])
+	require.False(t, ok)
+}
@@ -102,7 +102,7 @@ func (p *Provider) GenerateTopologyConfig(ctx context.Context, _ *int, instances
 	return root, nil
 }
 
-// Instances2NodeMap implements slurm.instanceMapper
+// Instances2NodeMap implements engines.instanceMapper
 func (p *Provider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
 	i2n := make(map[string]string)
 	for _, node := range nodes {
@@ -112,7 +112,7 @@ func (p *Provider) Instances2NodeMap(ctx context.Context, nodes []string) (map[s
 	return i2n, nil
 }
 
-// GetInstancesRegions implements slurm.instanceMapper
+// GetInstancesRegions implements engines.instanceMapper
 func (p *Provider) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
 	res := make(map[string]string)
 	for _, node := range nodes {
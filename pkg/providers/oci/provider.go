@@ -25,7 +25,7 @@ type baseProvider struct {
 
 // Engine support
 
-// GetInstancesRegions implements slurm.instanceMapper
+// GetInstancesRegions implements engines.instanceMapper
 func (p *baseProvider) GetInstancesRegions(ctx context.Context, nodes []string) (map[string]string, error) {
 	return getRegions(ctx, nodes)
 }
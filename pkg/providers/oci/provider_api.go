@@ -217,7 +217,7 @@ func (p *apiProvider) getComputeHostInfo(ctx context.Context, pageSize *int, ci
 
 // Engine support
 
-// Instances2NodeMap implements slurm.instanceMapper
+// Instances2NodeMap implements engines.instanceMapper
 func (p *apiProvider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
 	return instanceToNodeMap(ctx, nodes)
 }
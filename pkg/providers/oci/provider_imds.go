@@ -90,7 +90,7 @@ func (p *imdsProvider) getComputeHostInfo(ctx context.Context, ci topology.Compu
 
 // Engine support
 
-// Instances2NodeMap implements slurm.instanceMapper
+// Instances2NodeMap implements engines.instanceMapper
 func (p *imdsProvider) Instances2NodeMap(ctx context.Context, nodes []string) (map[string]string, error) {
 	i2n := make(map[string]string)
 
@@ -0,0 +1,29 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package providers
+
+import "context"
+
+type partialResultsKey struct{}
+
+// WithPartialResults returns the context carrying the partial results policy of the request
+func WithPartialResults(ctx context.Context, partial bool) context.Context {
+	return context.WithValue(ctx, partialResultsKey{}, partial)
+}
+
+// PartialResults returns true if the request accepts a partial topology:
+// the failures of some regions or sub-providers are reported as warnings
+// instead of failing the request
+func PartialResults(ctx context.Context) bool {
+	partial, _ := ctx.Value(partialResultsKey{}).(bool)
+	return partial
+}
+
+// Tolerable returns true if the error may be reported as a warning under the partial results policy;
+// client errors fail the request, since they are not resolved by retrying the other regions
+func Tolerable(ctx context.Context, code int) bool {
+	return PartialResults(ctx) && code >= 500
+}
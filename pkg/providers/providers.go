@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"net/http"
 	"os"
@@ -49,6 +50,11 @@ func NewRegistry(namedLoaders ...NamedLoader) Registry {
 	return Registry(component.NewRegistry(namedLoaders...))
 }
 
+// Register adds name/loader pairs to an existing Registry
+func (r Registry) Register(namedLoaders ...NamedLoader) {
+	component.Registry[Provider, Config](r).Register(namedLoaders...)
+}
+
 func (r Registry) Get(name string) (Loader, *httperr.Error) {
 	loader, ok := r[name]
 	if !ok {
@@ -58,6 +64,31 @@ func (r Registry) Get(name string) (Loader, *httperr.Error) {
 	return loader, nil
 }
 
+// SubProviderCredentials returns the credentials of a sub-provider of a provider wrapper,
+// passed to the wrapper with the "<provider name>." prefix
+func SubProviderCredentials(creds map[string]string, name string) map[string]string {
+	prefix := name + "."
+	var ret map[string]string
+	for key, val := range creds {
+		if k, ok := strings.CutPrefix(key, prefix); ok {
+			if ret == nil {
+				ret = make(map[string]string)
+			}
+			ret[k] = val
+		}
+	}
+	return ret
+}
+
+// ParamsHash returns a hash of the provider parameters, identifying the provider configuration
+// in the state kept across requests by the provider wrappers.
+// The map keys are printed in sorted order, so equal parameters have the same hash.
+func ParamsHash(params map[string]any) string {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%v", params)
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
 func HttpReq(ctx context.Context, method, url string, headers map[string]string) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
@@ -95,3 +95,13 @@ line2`,
 		})
 	}
 }
+
+func TestParamsHash(t *testing.T) {
+	a := map[string]any{"model_path": "a.yaml", "nested": map[string]any{"x": 1, "y": []any{"z"}}}
+	b := map[string]any{"nested": map[string]any{"y": []any{"z"}, "x": 1}, "model_path": "a.yaml"}
+	c := map[string]any{"model_path": "b.yaml", "nested": map[string]any{"x": 1, "y": []any{"z"}}}
+
+	require.Equal(t, ParamsHash(a), ParamsHash(b))
+	require.NotEqual(t, ParamsHash(a), ParamsHash(c))
+	require.Equal(t, ParamsHash(nil), ParamsHash(map[string]any{}))
+}
@@ -18,15 +18,26 @@ package registry
 
 import (
 	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/flux"
+	"github.com/NVIDIA/topograph/pkg/engines/graph"
+	"github.com/NVIDIA/topograph/pkg/engines/hostfile"
+	"github.com/NVIDIA/topograph/pkg/engines/json"
 	"github.com/NVIDIA/topograph/pkg/engines/k8s"
+	"github.com/NVIDIA/topograph/pkg/engines/pbs"
 	"github.com/NVIDIA/topograph/pkg/engines/slinky"
 	"github.com/NVIDIA/topograph/pkg/engines/slurm"
+	"github.com/NVIDIA/topograph/pkg/engines/volcano"
+	"github.com/NVIDIA/topograph/pkg/engines/webhook"
 
 	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/providers/aws"
+	"github.com/NVIDIA/topograph/pkg/providers/azure"
+	"github.com/NVIDIA/topograph/pkg/providers/cache"
+	"github.com/NVIDIA/topograph/pkg/providers/composite"
 	"github.com/NVIDIA/topograph/pkg/providers/crusoe"
 	"github.com/NVIDIA/topograph/pkg/providers/cw"
 	"github.com/NVIDIA/topograph/pkg/providers/dra"
+	"github.com/NVIDIA/topograph/pkg/providers/fallback"
 	"github.com/NVIDIA/topograph/pkg/providers/gcp"
 	"github.com/NVIDIA/topograph/pkg/providers/infiniband"
 	"github.com/NVIDIA/topograph/pkg/providers/lambdai"
@@ -39,6 +50,8 @@ import (
 var Providers = providers.NewRegistry(
 	aws.NamedLoader,
 	aws.NamedLoaderSim,
+	azure.NamedLoader,
+	azure.NamedLoaderSim,
 	crusoe.NamedLoader,
 	crusoe.NamedLoaderSim,
 	infiniband.NamedLoaderBM,
@@ -53,12 +66,28 @@ var Providers = providers.NewRegistry(
 	nebius.NamedLoader,
 	netq.NamedLoader,
 	lambdai.NamedLoader,
-	//TODO: add lambdai.NamedLoaderSim,
+	lambdai.NamedLoaderSim,
 	provider_test.NamedLoader,
 )
 
+// the provider wrappers load their sub-providers from the registry
+func init() {
+	Providers.Register(
+		composite.NamedLoader(Providers),
+		fallback.NamedLoader(Providers),
+		cache.NamedLoader(Providers),
+	)
+}
+
 var Engines = engines.NewRegistry(
+	flux.NamedLoader,
 	k8s.NamedLoader,
 	slurm.NamedLoader,
 	slinky.NamedLoader,
+	pbs.NamedLoader,
+	volcano.NamedLoader,
+	graph.NamedLoader,
+	hostfile.NamedLoader,
+	json.NamedLoader,
+	webhook.NamedLoader,
 )
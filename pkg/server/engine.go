@@ -18,13 +18,16 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/internal/httpreq"
+	"github.com/NVIDIA/topograph/pkg/engines"
 	"github.com/NVIDIA/topograph/pkg/metrics"
 	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/registry"
@@ -40,26 +43,53 @@ type asyncController struct {
 	queue *TrailingDelayQueue
 }
 
-func processRequest(item any) (any, *httperr.Error) {
-	return processRequestWithRetries(baseDelay, item.(*topology.Request), processTopologyRequest)
+// Result is the outcome of a topology request
+type Result struct {
+	// Output is the engine output of a request with a single engine
+	Output []byte
+	// Results maps engine names to their results for requests with multiple engines
+	Results map[string]*EngineResult
+	// Warnings lists the degradations of the generated topology
+	Warnings []topology.Warning
 }
 
-func processRequestWithRetries(delay time.Duration, tr *topology.Request, f func(*topology.Request) ([]byte, *httperr.Error)) ([]byte, *httperr.Error) {
-	attempt := 0
-	for {
-		var code int
-		attempt++
+// EngineResult is the outcome of a topology request for one of the request engines
+type EngineResult struct {
+	Status  int    `json:"status"`
+	Output  string `json:"output,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func processRequest(item any) (any, *httperr.Error) {
+	tr := item.(*topology.Request)
+	if len(tr.Engines) != 0 {
+		// the topology generation and each engine are retried separately,
+		// so that the engines that succeeded do not run again
 		start := time.Now()
+		res, err := processEnginesRequest(baseDelay, tr)
+		metrics.AddTopologyRequest(tr.Provider.Name, tr.EngineNames(), statusCode(err), time.Since(start))
+		return res, err
+	}
+	return processRequestWithRetries(baseDelay, tr, processTopologyRequest)
+}
 
+func processRequestWithRetries[T any](delay time.Duration, tr *topology.Request, f func(*topology.Request) (T, *httperr.Error)) (T, *httperr.Error) {
+	return withRetries(delay, func() (T, *httperr.Error) {
+		start := time.Now()
 		ret, err := f(tr)
-		if err != nil {
-			code = err.Code()
-		} else {
-			code = http.StatusOK
-		}
-		metrics.AddTopologyRequest(tr.Provider.Name, tr.Engine.Name, code, time.Since(start))
+		metrics.AddTopologyRequest(tr.Provider.Name, tr.EngineNames(), statusCode(err), time.Since(start))
+		return ret, err
+	})
+}
 
-		if !httpreq.ShouldRetry(code) || attempt == maxRetries {
+// withRetries calls the function until it succeeds, fails with an error that is not retried,
+// or reaches the maximum number of attempts
+func withRetries[T any](delay time.Duration, f func() (T, *httperr.Error)) (T, *httperr.Error) {
+	attempt := 0
+	for {
+		attempt++
+		ret, err := f()
+		if !httpreq.ShouldRetry(statusCode(err)) || attempt == maxRetries {
 			return ret, err
 		}
 
@@ -69,23 +99,117 @@ func processRequestWithRetries(delay time.Duration, tr *topology.Request, f func
 	}
 }
 
-func processTopologyRequest(tr *topology.Request) ([]byte, *httperr.Error) {
+func statusCode(err *httperr.Error) int {
+	if err != nil {
+		return err.Code()
+	}
+	return http.StatusOK
+}
+
+func processTopologyRequest(tr *topology.Request) (*Result, *httperr.Error) {
 	klog.InfoS("Creating topology config", "provider", tr.Provider.Name, "engine", tr.Engine.Name)
 	defer klog.Info("Topology request completed")
 
-	engLoader, err := registry.Engines.Get(tr.Engine.Name)
+	ctx := context.Background()
+
+	engs, err := loadEngines(ctx, []topology.Engine{tr.Engine})
 	if err != nil {
 		return nil, err
 	}
 
-	prvLoader, err := registry.Providers.Get(tr.Provider.Name)
+	root, err := generateTopology(ctx, tr, engs[0])
 	if err != nil {
 		return nil, err
 	}
 
+	out, err := engs[0].GenerateOutput(ctx, root, tr.Engine.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Output: out, Warnings: root.Warnings}, nil
+}
+
+// processEnginesRequest generates the topology once and passes it to every request engine.
+// The first engine discovers the compute nodes if the request does not list them.
+// The topology generation and each engine are retried on server errors.
+// With the "fail-all" policy, the request fails at the first failing engine and the remaining
+// engines are skipped; with the "best-effort" policy, all engines run and the request succeeds.
+func processEnginesRequest(delay time.Duration, tr *topology.Request) (*Result, *httperr.Error) {
+	klog.InfoS("Creating topology config", "provider", tr.Provider.Name, "engines", tr.EngineNames(), "policy", tr.EnginePolicy)
+	defer klog.Info("Topology request completed")
+
 	ctx := context.Background()
 
-	eng, err := engLoader(ctx, tr.Engine.Params)
+	engs, err := loadEngines(ctx, tr.Engines)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := withRetries(delay, func() (*topology.Vertex, *httperr.Error) {
+		return generateTopology(ctx, tr, engs[0])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*EngineResult)
+	failures := []string{}
+	var failure *httperr.Error
+	for i, e := range tr.Engines {
+		out, err := withRetries(delay, func() ([]byte, *httperr.Error) {
+			return engs[i].GenerateOutput(ctx, root, e.Params)
+		})
+		if err == nil {
+			results[e.Name] = &EngineResult{Status: http.StatusOK, Output: string(out)}
+			continue
+		}
+
+		klog.Errorf("Engine %s failed: %v", e.Name, err)
+		results[e.Name] = &EngineResult{Status: err.Code(), Message: err.Error()}
+		failures = append(failures, fmt.Sprintf("engine %s: %v", e.Name, err))
+		if failure == nil {
+			failure = err
+		}
+		if tr.EnginePolicy != topology.EnginePolicyBestEffort {
+			break
+		}
+	}
+
+	res := &Result{Results: results, Warnings: root.Warnings}
+	if failure != nil && tr.EnginePolicy != topology.EnginePolicyBestEffort {
+		return res, httperr.NewError(failure.Code(), strings.Join(failures, "; "))
+	}
+
+	return res, nil
+}
+
+// loadEngines loads the request engines
+func loadEngines(ctx context.Context, engs []topology.Engine) ([]engines.Engine, *httperr.Error) {
+	ret := make([]engines.Engine, 0, len(engs))
+	for _, e := range engs {
+		engLoader, err := registry.Engines.Get(e.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		eng, err := engLoader(ctx, engines.Config{
+			Creds:  e.Creds,
+			Params: e.Params,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eng)
+	}
+
+	return ret, nil
+}
+
+// generateTopology generates the topology graph with the request provider;
+// the engine discovers the compute nodes if the request does not list them
+func generateTopology(ctx context.Context, tr *topology.Request, eng engines.Engine) (*topology.Vertex, *httperr.Error) {
+	prvLoader, err := registry.Providers.Get(tr.Provider.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -119,18 +243,24 @@ func processTopologyRequest(tr *topology.Request) ([]byte, *httperr.Error) {
 		}
 	}
 
-	var root *topology.Vertex
 	if srv.cfg.FwdSvcURL != nil {
 		// forward the request to the global service
-		root, err = forwardRequest(ctx, tr, *srv.cfg.FwdSvcURL, computeInstances)
-	} else {
-		root, err = prv.GenerateTopologyConfig(ctx, srv.cfg.PageSize, computeInstances)
+		return forwardRequest(ctx, tr, *srv.cfg.FwdSvcURL, computeInstances)
 	}
+
+	ctx = providers.WithMaxConcurrency(ctx, srv.cfg.MaxConcurrency)
+	ctx = providers.WithPartialResults(ctx, tr.PartialResults)
+
+	root, err := prv.GenerateTopologyConfig(ctx, srv.cfg.PageSize, computeInstances)
 	if err != nil {
 		return nil, err
 	}
 
-	return eng.GenerateOutput(ctx, root, tr.Engine.Params)
+	for _, w := range root.Warnings {
+		metrics.AddTopologyWarning(tr.Provider.Name, w.Kind)
+	}
+
+	return root, nil
 }
 
 func checkCredentials(payloadCreds, cfgCreds map[string]string) map[string]string {
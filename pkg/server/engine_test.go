@@ -17,14 +17,18 @@
 package server
 
 import (
+	"context"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/topograph/internal/component"
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/pkg/config"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/registry"
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
@@ -206,14 +210,208 @@ SwitchName=S3 Nodes=I[34-36]
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			data, err := processTopologyRequest(tc.tr)
+			res, err := processTopologyRequest(tc.tr)
 			if len(tc.err) != 0 {
 				require.NotNil(t, err)
 				require.EqualError(t, err, tc.err)
 				require.Equal(t, tc.code, err.Code())
 			} else {
 				require.Nil(t, err)
-				require.Equal(t, tc.cfg, string(data))
+				require.Equal(t, tc.cfg, string(res.Output))
+			}
+		})
+	}
+}
+
+func TestProcessEnginesRequest(t *testing.T) {
+	srv = &HttpServer{
+		cfg: &config.Config{},
+	}
+	testCases := []struct {
+		name    string
+		tr      *topology.Request
+		results map[string]*EngineResult
+		err     string
+		code    int
+	}{
+		{
+			name: "Case 1: invalid engine name",
+			tr: &topology.Request{
+				Provider: topology.Provider{Name: "test"},
+				Engines:  []topology.Engine{{Name: "slurm"}, {Name: "bad"}},
+			},
+			err:  `unsupported engine "bad"`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "Case 2: all engines succeed",
+			tr: &topology.Request{
+				Provider: topology.Provider{Name: "test"},
+				Engines:  []topology.Engine{{Name: "slurm"}, {Name: "hostfile", Params: map[string]any{"nodeCount": 2}}},
+			},
+			results: map[string]*EngineResult{
+				"slurm":    {Status: http.StatusOK, Output: "SwitchName=S1 Switches=S[2-3]\nSwitchName=S2 Nodes=Node[201-202,205]\nSwitchName=S3 Nodes=Node[304-306]\n"},
+				"hostfile": {Status: http.StatusOK, Output: "Node201\nNode202\n"},
+			},
+		},
+		{
+			name: "Case 3: fail all",
+			tr: &topology.Request{
+				Provider:     topology.Provider{Name: "test"},
+				Engines:      []topology.Engine{{Name: "hostfile", Params: map[string]any{"nodeCount": 10}}, {Name: "slurm"}},
+				EnginePolicy: topology.EnginePolicyFailAll,
+			},
+			results: map[string]*EngineResult{
+				"hostfile": {Status: http.StatusBadRequest, Message: "requested 10 nodes, but the topology has 6 nodes"},
+			},
+			err:  "engine hostfile: requested 10 nodes, but the topology has 6 nodes",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "Case 4: best effort",
+			tr: &topology.Request{
+				Provider:     topology.Provider{Name: "test"},
+				Engines:      []topology.Engine{{Name: "hostfile", Params: map[string]any{"nodeCount": 10}}, {Name: "slurm"}},
+				EnginePolicy: topology.EnginePolicyBestEffort,
+			},
+			results: map[string]*EngineResult{
+				"hostfile": {Status: http.StatusBadRequest, Message: "requested 10 nodes, but the topology has 6 nodes"},
+				"slurm":    {Status: http.StatusOK, Output: "SwitchName=S1 Switches=S[2-3]\nSwitchName=S2 Nodes=Node[201-202,205]\nSwitchName=S3 Nodes=Node[304-306]\n"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := processEnginesRequest(time.Millisecond, tc.tr)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+				require.Equal(t, tc.code, err.Code())
+			} else {
+				require.Nil(t, err)
+			}
+			var results map[string]*EngineResult
+			if res != nil {
+				results = res.Results
+			}
+			require.Equal(t, tc.results, results)
+		})
+	}
+}
+
+// countingEngine fails the first calls of GenerateOutput with the given codes
+type countingEngine struct {
+	codes []int
+	calls int
+}
+
+func (eng *countingEngine) GetComputeInstances(_ context.Context, _ engines.Environment) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, engines.ErrNodesRequired("counting")
+}
+
+func (eng *countingEngine) GenerateOutput(_ context.Context, _ *topology.Vertex, _ map[string]any) ([]byte, *httperr.Error) {
+	eng.calls++
+	if len(eng.codes) != 0 {
+		code := eng.codes[0]
+		eng.codes = eng.codes[1:]
+		return nil, httperr.NewError(code, "error")
+	}
+	return []byte("OK\n"), nil
+}
+
+func TestProcessEnginesRequestRetries(t *testing.T) {
+	srv = &HttpServer{
+		cfg: &config.Config{},
+	}
+	ok := &countingEngine{}
+	flaky := &countingEngine{codes: []int{http.StatusInternalServerError, http.StatusBadGateway}}
+	for name, eng := range map[string]*countingEngine{"ok": ok, "flaky": flaky} {
+		registry.Engines.Register(component.Named(name, func(context.Context, engines.Config) (engines.Engine, *httperr.Error) {
+			return eng, nil
+		}))
+		defer delete(registry.Engines, name)
+	}
+
+	res, err := processEnginesRequest(time.Millisecond, &topology.Request{
+		Provider: topology.Provider{Name: "test"},
+		Engines:  []topology.Engine{{Name: "ok"}, {Name: "flaky"}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, map[string]*EngineResult{
+		"ok":    {Status: http.StatusOK, Output: "OK\n"},
+		"flaky": {Status: http.StatusOK, Output: "OK\n"},
+	}, res.Results)
+	// only the failing engine is retried
+	require.Equal(t, 1, ok.calls)
+	require.Equal(t, 3, flaky.calls)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		tr     *topology.Request
+		policy string
+		err    string
+	}{
+		{
+			name: "Case 1: single engine",
+			tr: &topology.Request{
+				Provider: topology.Provider{Name: "test"},
+				Engine:   topology.Engine{Name: "slurm"},
+			},
+		},
+		{
+			name: "Case 2: multiple engines with default policy",
+			tr: &topology.Request{
+				Provider: topology.Provider{Name: "test"},
+				Engines:  []topology.Engine{{Name: "slurm"}, {Name: "graph"}},
+			},
+			policy: topology.EnginePolicyFailAll,
+		},
+		{
+			name: "Case 3: engine and engines",
+			tr: &topology.Request{
+				Provider: topology.Provider{Name: "test"},
+				Engine:   topology.Engine{Name: "slurm"},
+				Engines:  []topology.Engine{{Name: "graph"}},
+			},
+			err: "engine and engines are mutually exclusive",
+		},
+		{
+			name: "Case 4: bad policy",
+			tr: &topology.Request{
+				Provider:     topology.Provider{Name: "test"},
+				Engines:      []topology.Engine{{Name: "slurm"}},
+				EnginePolicy: "retry",
+			},
+			err: "unsupported engine policy retry",
+		},
+		{
+			name: "Case 5: duplicate engines",
+			tr: &topology.Request{
+				Provider: topology.Provider{Name: "test"},
+				Engines:  []topology.Engine{{Name: "slurm"}, {Name: "slurm"}},
+			},
+			err: "duplicate engine slurm",
+		},
+		{
+			name: "Case 6: unsupported engine",
+			tr: &topology.Request{
+				Provider: topology.Provider{Name: "test"},
+				Engines:  []topology.Engine{{Name: "slurm"}, {Name: "bad"}},
+			},
+			err: "unsupported engine bad",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate(tc.tr)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.policy, tc.tr.EnginePolicy)
 			}
 		})
 	}
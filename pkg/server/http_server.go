@@ -18,6 +18,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -40,6 +41,9 @@ type HttpServer struct {
 	async *asyncController
 }
 
+// HeaderWarning is the response header listing the warnings of the generated topology
+const HeaderWarning = "X-Topograph-Warning"
+
 var srv *HttpServer
 
 func InitHttpServer(ctx context.Context, cfg *config.Config) {
@@ -159,14 +163,14 @@ func readRequest(w http.ResponseWriter, r *http.Request) *topology.Request {
 	if len(tr.Provider.Name) == 0 {
 		tr.Provider.Name = srv.cfg.Provider
 	}
-	if len(tr.Engine.Name) == 0 {
+	if len(tr.Engine.Name) == 0 && len(tr.Engines) == 0 {
 		tr.Engine.Name = srv.cfg.Engine
 	}
 
 	klog.Info(tr.String())
 
 	if err = validate(tr); err != nil {
-		return httpError(w, tr.Provider.Name, tr.Engine.Name, err.Error(), http.StatusBadRequest, time.Since(start))
+		return httpError(w, tr.Provider.Name, tr.EngineNames(), err.Error(), http.StatusBadRequest, time.Since(start))
 	}
 
 	return tr
@@ -183,9 +187,37 @@ func validate(tr *topology.Request) error {
 		}
 	}
 
-	_, exists = registry.Engines[tr.Engine.Name]
+	if len(tr.Engines) != 0 {
+		if len(tr.Engine.Name) != 0 {
+			return fmt.Errorf("engine and engines are mutually exclusive")
+		}
+		switch tr.EnginePolicy {
+		case "":
+			tr.EnginePolicy = topology.EnginePolicyFailAll
+		case topology.EnginePolicyFailAll, topology.EnginePolicyBestEffort:
+		default:
+			return fmt.Errorf("unsupported engine policy %s", tr.EnginePolicy)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, eng := range tr.GetEngines() {
+		if err := validateEngine(eng.Name); err != nil {
+			return err
+		}
+		if names[eng.Name] {
+			return fmt.Errorf("duplicate engine %s", eng.Name)
+		}
+		names[eng.Name] = true
+	}
+
+	return nil
+}
+
+func validateEngine(name string) error {
+	_, exists := registry.Engines[name]
 	if !exists {
-		switch tr.Engine.Name {
+		switch name {
 
 		// case common.EngineSLURM, common.EngineTest:
 		// 	//nop
@@ -198,7 +230,7 @@ func validate(tr *topology.Request) error {
 		case "":
 			return fmt.Errorf("no engine given for topology request")
 		default:
-			return fmt.Errorf("unsupported engine %s", tr.Engine.Name)
+			return fmt.Errorf("unsupported engine %s", name)
 		}
 	}
 	// TODO: Validate K8s params
@@ -220,12 +252,25 @@ func getresult(w http.ResponseWriter, r *http.Request) {
 	}
 
 	res := srv.async.queue.Get(uid)
+	for _, warning := range topology.SummarizeWarnings(res.Warnings) {
+		w.Header().Add(HeaderWarning, warning)
+	}
 
-	switch res.Status {
-	case http.StatusOK:
+	switch {
+	case res.Results != nil:
+		// requests with multiple engines return the engine results
+		data, err := json.Marshal(res.Results)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(res.Status)
+		_, _ = w.Write(data)
+	case res.Status == http.StatusOK:
 		w.WriteHeader(res.Status)
 		_, _ = w.Write(res.Ret.([]byte))
-	case http.StatusAccepted:
+	case res.Status == http.StatusAccepted:
 		w.WriteHeader(res.Status)
 		_, _ = w.Write([]byte(res.Message))
 	default:
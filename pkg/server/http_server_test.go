@@ -53,6 +53,23 @@ SwitchName=S2 Nodes=Node[201-202,205]
 SwitchName=S3 Nodes=Node[304-306]
 `
 
+	multiEnginePayload = `
+{
+  "provider": {
+    "name": "%s"
+  },
+  "engines": [
+    {
+      "name": "slurm"
+    },
+    {
+      "name": "hostfile"
+    }
+  ]
+}
+`
+	multiEngineResults = `{"hostfile":{"status":200,"output":"Node201\nNode202\nNode205\nNode304\nNode305\nNode306\n"},"slurm":{"status":200,"output":"SwitchName=S1 Switches=S[2-3]\nSwitchName=S2 Nodes=Node[201-202,205]\nSwitchName=S3 Nodes=Node[304-306]\n"}}`
+
 	slurmTreePayload = `
 {
   "provider": {
@@ -92,6 +109,40 @@ SwitchName=sw14 Nodes=n-[1401-1402]
 SwitchName=no-topology Nodes=n-CPU
 `
 
+	remoteEnginesPayload = `
+{
+  "provider": {
+    "name": "%s",
+    "params": {
+      "model_path": "../../tests/models/medium.yaml"
+    }
+  },
+  "engines": [
+    {
+      "name": "slurm"
+    },
+    {
+      "name": "hostfile",
+      "params": {
+        "nodeCount": 2
+      }
+    }
+  ],
+  "nodes": [
+    {
+      "region": "R1",
+      "instances": {
+        "1101": "n-1101",
+        "1102": "n-1102",
+        "1201": "n-1201",
+        "1202": "n-1202"
+      }
+    }
+  ]
+}
+`
+	remoteEnginesResults = `{"hostfile":{"status":200,"output":"n-1101\nn-1102\n"},"slurm":{"status":200,"output":"SwitchName=sw3 Switches=sw21\nSwitchName=sw21 Switches=sw[11-12]\nSwitchName=sw11 Nodes=n-[1101-1102]\nSwitchName=sw12 Nodes=n-[1201-1202]\n"}}`
+
 	slurmBlockPayload = `
 {
   "provider": {
@@ -218,6 +269,16 @@ func TestServerLocal(t *testing.T) {
 			payload:  slurmTreePayload,
 			expected: slurmTreeConfig,
 		},
+		{
+			name:     "Case 7: send test request for multiple engines",
+			endpoint: "generate",
+			provider: "test",
+			payload:  multiEnginePayload,
+			expected: multiEngineResults,
+			metrics: []string{
+				`topograph_request_duration_seconds_count\{engine="slurm,hostfile",provider="test",status="200"\} 1`,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -341,6 +402,12 @@ func TestServerRemote(t *testing.T) {
 			payload:  slurmBlockPayload,
 			expected: slurmBlockConfig,
 		},
+		{
+			name:     "Case 3: send request for multiple engines",
+			model:    "../../tests/models/medium.yaml",
+			payload:  remoteEnginesPayload,
+			expected: remoteEnginesResults,
+		},
 	}
 
 	for _, tc := range testCases {
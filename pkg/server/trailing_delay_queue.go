@@ -27,6 +27,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
 const RequestHistorySize = 100
@@ -37,6 +38,10 @@ type Completion struct {
 	Ret     any
 	Status  int
 	Message string
+	// Results maps engine names to their results for requests with multiple engines
+	Results map[string]*EngineResult
+	// Warnings lists the degradations of the generated topology
+	Warnings []topology.Warning
 }
 
 type TrailingDelayQueue struct {
@@ -86,7 +91,13 @@ func (q *TrailingDelayQueue) run() {
 
 			if item != nil {
 				res := &Completion{}
-				if data, err := q.handle(item); err != nil {
+				data, err := q.handle(item)
+				if r, ok := data.(*Result); ok && r != nil {
+					res.Results = r.Results
+					res.Warnings = r.Warnings
+					data = r.Output
+				}
+				if err != nil {
 					res.Status = err.Code()
 					res.Message = err.Error()
 					klog.Errorf("HTTP %d: %s", res.Status, res.Message)
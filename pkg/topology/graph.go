@@ -19,6 +19,7 @@ package topology
 import (
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
 	"strings"
 
@@ -37,6 +38,7 @@ const (
 
 type ClusterTopology struct {
 	Instances []*InstanceTopology
+	Warnings  []Warning
 }
 
 type InstanceTopology struct {
@@ -86,6 +88,11 @@ func (c *ClusterTopology) Append(inst *InstanceTopology) {
 	c.Instances = append(c.Instances, inst)
 }
 
+func (c *ClusterTopology) AddWarning(w Warning) {
+	klog.Warning(w.String())
+	c.Warnings = append(c.Warnings, w)
+}
+
 func (c *ClusterTopology) Len() int {
 	return len(c.Instances)
 }
@@ -167,6 +174,7 @@ func (c *ClusterTopology) ToThreeTierGraph(provider string, cis []ComputeInstanc
 
 	root := &Vertex{
 		Vertices: make(map[string]*Vertex),
+		Warnings: slices.Clone(c.Warnings),
 	}
 	root.Vertices[TopologyTree] = treeRoot
 
@@ -23,10 +23,25 @@ import (
 	"strings"
 )
 
+// Engine failure policies of the requests with multiple engines
+const (
+	// EnginePolicyFailAll stops at the first failing engine and fails the request
+	EnginePolicyFailAll = "fail-all"
+	// EnginePolicyBestEffort runs all engines and reports the result of each
+	EnginePolicyBestEffort = "best-effort"
+)
+
 type Request struct {
-	Provider Provider           `json:"provider"`
-	Engine   Engine             `json:"engine"`
-	Nodes    []ComputeInstances `json:"nodes"`
+	Provider Provider `json:"provider"`
+	Engine   Engine   `json:"engine"`
+	// Engines (optional) lists the engines receiving the same topology; mutually exclusive with Engine
+	Engines []Engine `json:"engines,omitempty"`
+	// EnginePolicy (optional) specifies the failure policy of the Engines
+	EnginePolicy string `json:"enginePolicy,omitempty"`
+	// PartialResults (optional) accepts a partial topology if some regions or sub-providers fail;
+	// the failures are reported as warnings
+	PartialResults bool               `json:"partialResults,omitempty"`
+	Nodes          []ComputeInstances `json:"nodes"`
 }
 
 type Provider struct {
@@ -36,8 +51,9 @@ type Provider struct {
 }
 
 type Engine struct {
-	Name   string         `json:"name"`
-	Params map[string]any `json:"params"`
+	Name   string            `json:"name"`
+	Creds  map[string]string `json:"creds"` // access credentials
+	Params map[string]any    `json:"params"`
 }
 
 type ComputeInstances struct {
@@ -58,8 +74,19 @@ func (p *Request) String() string {
 	sb.WriteString(fmt.Sprintf("  Provider:%s\n", spacer(p.Provider.Name)))
 	sb.WriteString(map2string(p.Provider.Creds, "  Credentials", true, "\n"))
 	sb.WriteString(map2string(p.Provider.Params, "  Parameters", false, "\n"))
-	sb.WriteString(fmt.Sprintf("  Engine:%s\n", spacer(p.Engine.Name)))
-	sb.WriteString(map2string(p.Engine.Params, "  Parameters", false, "\n"))
+	for _, eng := range p.GetEngines() {
+		sb.WriteString(fmt.Sprintf("  Engine:%s\n", spacer(eng.Name)))
+		if len(eng.Creds) != 0 {
+			sb.WriteString(map2string(eng.Creds, "  Credentials", true, "\n"))
+		}
+		sb.WriteString(map2string(eng.Params, "  Parameters", false, "\n"))
+	}
+	if len(p.EnginePolicy) != 0 {
+		sb.WriteString(fmt.Sprintf("  EnginePolicy: %s\n", p.EnginePolicy))
+	}
+	if p.PartialResults {
+		sb.WriteString("  PartialResults: true\n")
+	}
 	sb.WriteString("  Nodes:")
 	for _, nodes := range p.Nodes {
 		sb.WriteByte(' ')
@@ -69,6 +96,24 @@ func (p *Request) String() string {
 	return sb.String()
 }
 
+// GetEngines returns the engines of the request
+func (p *Request) GetEngines() []Engine {
+	if len(p.Engines) != 0 {
+		return p.Engines
+	}
+	return []Engine{p.Engine}
+}
+
+// EngineNames returns the comma-separated engine names
+func (p *Request) EngineNames() string {
+	engines := p.GetEngines()
+	names := make([]string, 0, len(engines))
+	for _, eng := range engines {
+		names = append(names, eng.Name)
+	}
+	return strings.Join(names, ",")
+}
+
 func GetTopologyRequest(body []byte) (*Request, error) {
 	var payload Request
 
@@ -140,6 +140,47 @@ func TestPayload(t *testing.T) {
   Engine: slurm
   Parameters: [block_sizes:30,120 plugin:topology/block reconfigure:true]
   Nodes: region1: [instance1:node1 instance2:node2 instance3:node3] region2: [instance4:node4 instance5:node5 instance6:node6]
+`,
+		},
+		{
+			name: "Case 4: multiple engines",
+			input: `
+{
+  "provider": {
+    "name": "test"
+  },
+  "engines": [
+    {
+      "name": "k8s"
+    },
+    {
+      "name": "slinky",
+      "params": {
+        "namespace": "slurm"
+      }
+    }
+  ],
+  "enginePolicy": "best-effort"
+}
+`,
+			payload: &Request{
+				Provider: Provider{Name: "test"},
+				Engines: []Engine{
+					{Name: "k8s"},
+					{Name: "slinky", Params: map[string]any{KeyNamespace: "slurm"}},
+				},
+				EnginePolicy: EnginePolicyBestEffort,
+			},
+			print: `TopologyRequest:
+  Provider: test
+  Credentials: []
+  Parameters: []
+  Engine: k8s
+  Parameters: []
+  Engine: slinky
+  Parameters: [namespace:slurm]
+  EnginePolicy: best-effort
+  Nodes:
 `,
 		},
 	}
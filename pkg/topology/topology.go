@@ -18,6 +18,8 @@ package topology
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -34,6 +36,8 @@ const (
 
 	KeyPlugin      = "plugin"
 	KeyGeneratedAt = "generated_at"
+	KeyProvider    = "provider"
+	KeyWarning     = "warning"
 	TopologyTree   = "topology/tree"
 	TopologyBlock  = "topology/block"
 	TopologyFlat   = "topology/flat"
@@ -52,15 +56,38 @@ const (
 	KeyConfigMapNamespace         = "topograph.nvidia.com/slurm-namespace"
 )
 
+// HeaderKeys lists the keys of the header comments of the generated configs: the root metadata keys
+// and the warnings. The header comments are ignored when comparing the configs.
+var HeaderKeys = []string{KeyGeneratedAt, KeyProvider, KeyWarning}
+
 // Vertex is a tree node, representing a compute node or a network switch, where
 // - Name is a compute node name
 // - ID is an CSP defined instance ID of switches and compute nodes
 // - Vertices is a list of connected compute nodes or network switches
+// - Warnings lists the degradations of the topology, set in the root vertex
 type Vertex struct {
 	Name     string
 	ID       string
 	Vertices map[string]*Vertex
 	Metadata map[string]string
+	Warnings []Warning
+}
+
+// Copy returns a deep copy of the vertex, which can be modified without affecting the original
+func (v *Vertex) Copy() *Vertex {
+	ret := &Vertex{
+		Name:     v.Name,
+		ID:       v.ID,
+		Metadata: maps.Clone(v.Metadata),
+		Warnings: slices.Clone(v.Warnings),
+	}
+	if v.Vertices != nil {
+		ret.Vertices = make(map[string]*Vertex, len(v.Vertices))
+		for key, w := range v.Vertices {
+			ret.Vertices[key] = w.Copy()
+		}
+	}
+	return ret
 }
 
 func (v *Vertex) String() string {
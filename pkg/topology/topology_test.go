@@ -0,0 +1,35 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVertexCopy(t *testing.T) {
+	root := &Vertex{
+		Vertices: map[string]*Vertex{
+			TopologyTree: createVertex("", createVertex("S1", createVertex("n1"))),
+		},
+		Metadata: map[string]string{KeyProvider: "test"},
+		Warnings: []Warning{{Kind: WarningProvider, Message: "warning"}},
+	}
+
+	cp := root.Copy()
+	require.Equal(t, root, cp)
+
+	cp.Vertices[TopologyTree].Vertices["S1"].Vertices["n2"] = createVertex("n2")
+	cp.Vertices[TopologyBlock] = createVertex("")
+	cp.Metadata[KeyProvider] = "copy"
+	cp.Warnings[0].Message = "copy"
+
+	require.Len(t, root.Vertices, 1)
+	require.Len(t, root.Vertices[TopologyTree].Vertices["S1"].Vertices, 1)
+	require.Equal(t, "test", root.Metadata[KeyProvider])
+	require.Equal(t, "warning", root.Warnings[0].Message)
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Warning kinds
+const (
+	// WarningRegion reports a region that failed to return the topology of its instances
+	WarningRegion = "region"
+	// WarningProvider reports a provider that failed to return its part of the topology
+	WarningProvider = "provider"
+	// WarningTopology reports a node with missing or invalid network topology data
+	WarningTopology = "topology"
+	// WarningNVLink reports a node with incomplete NVLink domain data
+	WarningNVLink = "nvlink"
+	// WarningConflict reports a node placed differently by the sub-providers of a composite provider
+	WarningConflict = "conflict"
+)
+
+const (
+	// maxWarnings limits the number of warnings in the header comments and response headers
+	maxWarnings = 20
+	// maxWarningExamples limits the number of node warnings quoted in a summary
+	maxWarningExamples = 3
+)
+
+// Warning reports a degradation of the generated topology, which is otherwise valid
+type Warning struct {
+	Kind    string `json:"kind"`
+	Region  string `json:"region,omitempty"`
+	Node    string `json:"node,omitempty"`
+	Message string `json:"message"`
+}
+
+// String returns the warning message on a single line; the message may hold upstream
+// error bodies, so line breaks and control characters are collapsed into spaces
+func (w Warning) String() string {
+	return strings.Join(strings.FieldsFunc(w.Message, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}), " ")
+}
+
+// RegionWarning reports a region failure tolerated under the partial results policy
+func RegionWarning(region string, err error) Warning {
+	return Warning{
+		Kind:    WarningRegion,
+		Region:  region,
+		Message: fmt.Sprintf("region %s failed: %v", region, err),
+	}
+}
+
+// MissingTopologyWarning reports a node missing the topology data, e.g. "PhysicalHostTopology"
+func MissingTopologyWarning(region, node, data string) Warning {
+	return Warning{
+		Kind:    WarningTopology,
+		Region:  region,
+		Node:    node,
+		Message: fmt.Sprintf("node %s missing %s", node, data),
+	}
+}
+
+// IncompleteNVLinkWarning reports a node with incomplete NVLink domain data
+func IncompleteNVLinkWarning(region, node, detail string) Warning {
+	return Warning{
+		Kind:    WarningNVLink,
+		Region:  region,
+		Node:    node,
+		Message: fmt.Sprintf("NVL data incomplete for %s: %s", node, detail),
+	}
+}
+
+// Header returns the key/value pairs of the header comments of the generated configs:
+// the root metadata listed in HeaderKeys, followed by the warnings
+func (v *Vertex) Header() [][2]string {
+	header := [][2]string{}
+	for _, key := range HeaderKeys {
+		if val := v.Metadata[key]; len(val) != 0 {
+			header = append(header, [2]string{key, val})
+		}
+	}
+	for _, w := range SummarizeWarnings(v.Warnings) {
+		header = append(header, [2]string{KeyWarning, w})
+	}
+	return header
+}
+
+// SummarizeWarnings returns the warning lines for the header comments and response headers.
+// The node warnings are aggregated by kind and region with the node count and a few examples,
+// and the number of lines is capped.
+func SummarizeWarnings(warnings []Warning) []string {
+	type group struct {
+		kind, region string
+		warnings     []Warning
+	}
+	groups := []*group{}
+	index := make(map[[2]string]*group)
+	for _, w := range warnings {
+		if len(w.Node) == 0 {
+			groups = append(groups, &group{warnings: []Warning{w}})
+			continue
+		}
+		key := [2]string{w.Kind, w.Region}
+		g, ok := index[key]
+		if !ok {
+			g = &group{kind: w.Kind, region: w.Region}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.warnings = append(g.warnings, w)
+	}
+
+	lines := []string{}
+	for _, g := range groups {
+		if len(lines) == maxWarnings {
+			lines = append(lines, fmt.Sprintf("%d more warnings", len(groups)-maxWarnings))
+			break
+		}
+		if len(g.warnings) == 1 {
+			lines = append(lines, g.warnings[0].String())
+			continue
+		}
+		examples := []string{}
+		for _, w := range g.warnings[:min(len(g.warnings), maxWarningExamples)] {
+			examples = append(examples, w.String())
+		}
+		line := fmt.Sprintf("%s warnings for %d nodes", g.kind, len(g.warnings))
+		if len(g.region) != 0 {
+			line += " in region " + g.region
+		}
+		lines = append(lines, fmt.Sprintf("%s, e.g. %s", line, strings.Join(examples, "; ")))
+	}
+	return lines
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeader(t *testing.T) {
+	c := NewClusterTopology()
+	c.Append(&InstanceTopology{InstanceID: "i1", BlockID: "b1", AcceleratorID: "nvl1"})
+	c.AddWarning(RegionWarning("r2", errors.New("API error")))
+	c.AddWarning(IncompleteNVLinkWarning("r1", "n2", `CliqueID=""`))
+
+	root := c.ToThreeTierGraph("test", []ComputeInstances{
+		{Region: "r1", Instances: map[string]string{"i1": "n1", "i2": "n2"}},
+	}, false)
+	root.Metadata = map[string]string{KeyGeneratedAt: "2026-01-02T03:04:05Z"}
+
+	require.Equal(t, [][2]string{
+		{KeyGeneratedAt, "2026-01-02T03:04:05Z"},
+		{KeyWarning, "region r2 failed: API error"},
+		{KeyWarning, `NVL data incomplete for n2: CliqueID=""`},
+	}, root.Header())
+
+	require.Empty(t, (&Vertex{}).Header())
+}
+
+func TestWarningString(t *testing.T) {
+	w := RegionWarning("r1", errors.New("API error: 503\r\n<html>\n\tService Unavailable\x00\n</html>\n"))
+	require.Equal(t, "region r1 failed: API error: 503 <html> Service Unavailable </html>", w.String())
+}
+
+func TestSummarizeWarnings(t *testing.T) {
+	require.Empty(t, SummarizeWarnings(nil))
+
+	warnings := []Warning{RegionWarning("r2", errors.New("API error"))}
+	for i := range 2000 {
+		warnings = append(warnings, MissingTopologyWarning("r1", fmt.Sprintf("n%d", i), "placementGroupId"))
+	}
+	warnings = append(warnings, IncompleteNVLinkWarning("r1", "n1", "cluster ID"))
+
+	require.Equal(t, []string{
+		"region r2 failed: API error",
+		"topology warnings for 2000 nodes in region r1, e.g. node n0 missing placementGroupId; " +
+			"node n1 missing placementGroupId; node n2 missing placementGroupId",
+		"NVL data incomplete for n1: cluster ID",
+	}, SummarizeWarnings(warnings))
+
+	warnings = []Warning{}
+	for i := range 25 {
+		warnings = append(warnings, RegionWarning(fmt.Sprintf("r%d", i), errors.New("API error")))
+	}
+	lines := SummarizeWarnings(warnings)
+	require.Len(t, lines, maxWarnings+1)
+	require.Equal(t, "region r19 failed: API error", lines[maxWarnings-1])
+	require.Equal(t, "5 more warnings", lines[maxWarnings])
+}
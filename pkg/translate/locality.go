@@ -0,0 +1,217 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// localityGroup is a switch, or an NVLink block in block-only topologies,
+// with its nodes and child groups
+type localityGroup struct {
+	id     string
+	nodes  []string
+	groups []*localityGroup
+	// size is the number of nodes in the subtree
+	size int
+	// height is 1 for the groups without child groups
+	height int
+}
+
+// LocalityOrder returns the node names in network locality order. The tree is walked
+// depth-first in the sorted adjacency order, the nodes of a switch are grouped by their
+// NVLink blocks, and the nodes without topology come last. In block-only topologies
+// the nodes are ordered by block.
+// If count is positive, it returns the subset of count nodes that uses the fewest switches:
+// the nodes are taken from the lowest switch with enough nodes, preferring the child
+// switches that fit the remaining nodes best.
+func (nt *NetworkTopology) LocalityOrder(count int) ([]string, error) {
+	root := nt.localityTree()
+	if count <= 0 {
+		return root.order(), nil
+	}
+
+	if count > root.size {
+		return nil, fmt.Errorf("requested %d nodes, but the topology has %d nodes", count, root.size)
+	}
+
+	selected := make(map[string]bool)
+	for _, node := range root.candidate(count).pick(count) {
+		selected[node] = true
+	}
+
+	nodes := make([]string, 0, count)
+	for _, node := range root.order() {
+		if selected[node] {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes, nil
+}
+
+func (nt *NetworkTopology) localityTree() *localityGroup {
+	if len(nt.tree) != 0 {
+		root := nt.newLocalityGroup("")
+		// nodes without topology come last
+		slices.SortStableFunc(root.groups, func(a, b *localityGroup) int {
+			return boolCmp(a.id == topology.NoTopology, b.id == topology.NoTopology)
+		})
+		return root
+	}
+
+	root := &localityGroup{height: 2}
+	for _, block := range nt.blocks {
+		g := &localityGroup{id: block.id, nodes: slices.Sorted(slices.Values(block.nodes)), height: 1}
+		g.size = len(g.nodes)
+		root.groups = append(root.groups, g)
+		root.size += g.size
+	}
+
+	return root
+}
+
+func (nt *NetworkTopology) newLocalityGroup(id string) *localityGroup {
+	g := &localityGroup{id: id}
+	for _, child := range nt.tree[id] {
+		v, ok := nt.vertices[child]
+		if !ok {
+			continue
+		}
+		if len(v.Vertices) != 0 {
+			sub := nt.newLocalityGroup(child)
+			g.groups = append(g.groups, sub)
+			g.size += sub.size
+			g.height = max(g.height, sub.height)
+		} else if len(v.Name) != 0 {
+			g.nodes = append(g.nodes, v.Name)
+		}
+	}
+	g.size += len(g.nodes)
+	g.height++
+
+	// keep the nodes of the same NVLink block together, ordering the blocks by their first node
+	first := make(map[string]string)
+	for _, node := range g.nodes {
+		if block := nt.blockID(node); len(block) != 0 {
+			if f, ok := first[block]; !ok || node < f {
+				first[block] = node
+			}
+		}
+	}
+	key := func(node string) string {
+		if f, ok := first[nt.blockID(node)]; ok {
+			return f
+		}
+		return node
+	}
+	sort.SliceStable(g.nodes, func(i, j int) bool {
+		if ki, kj := key(g.nodes[i]), key(g.nodes[j]); ki != kj {
+			return ki < kj
+		}
+		return g.nodes[i] < g.nodes[j]
+	})
+
+	return g
+}
+
+// blockID returns the block ID of the node, or an empty string for nodes without a block
+func (nt *NetworkTopology) blockID(node string) string {
+	if info, ok := nt.nodeInfo[node]; ok {
+		return info.blockID
+	}
+	return ""
+}
+
+// order returns the nodes of the group in depth-first order
+func (g *localityGroup) order() []string {
+	nodes := slices.Clone(g.nodes)
+	for _, sub := range g.groups {
+		nodes = append(nodes, sub.order()...)
+	}
+	return nodes
+}
+
+// candidate returns the lowest, and then the smallest, group with at least count nodes
+func (g *localityGroup) candidate(count int) *localityGroup {
+	if g.size < count {
+		return nil
+	}
+	best := g
+	for _, sub := range g.groups {
+		if sub.id == topology.NoTopology {
+			continue
+		}
+		if c := sub.candidate(count); c != nil && (c.height < best.height || (c.height == best.height && c.size < best.size)) {
+			best = c
+		}
+	}
+	return best
+}
+
+// pick returns count nodes of the group. The own nodes are taken first. Then, if a child group
+// fits the remaining nodes, the smallest such group is used; otherwise the largest group is
+// taken whole. The nodes without topology are taken last.
+func (g *localityGroup) pick(count int) []string {
+	if count >= g.size {
+		return g.order()
+	}
+
+	nodes := slices.Clone(g.nodes[:min(count, len(g.nodes))])
+	remaining := []*localityGroup{}
+	var noTopology *localityGroup
+	for _, sub := range g.groups {
+		if sub.id == topology.NoTopology {
+			noTopology = sub
+		} else {
+			remaining = append(remaining, sub)
+		}
+	}
+
+	for len(nodes) < count && len(remaining) != 0 {
+		need := count - len(nodes)
+		var fit *localityGroup
+		for _, sub := range remaining {
+			if sub.size >= need && (fit == nil || sub.size < fit.size) {
+				fit = sub
+			}
+		}
+		if fit != nil {
+			nodes = append(nodes, fit.pick(need)...)
+			break
+		}
+
+		largest := 0
+		for i, sub := range remaining {
+			if sub.size > remaining[largest].size {
+				largest = i
+			}
+		}
+		nodes = append(nodes, remaining[largest].order()...)
+		remaining = slices.Delete(remaining, largest, largest+1)
+	}
+
+	if len(nodes) < count && noTopology != nil {
+		nodes = append(nodes, noTopology.pick(count-len(nodes))...)
+	}
+
+	return nodes
+}
+
+func boolCmp(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
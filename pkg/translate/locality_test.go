@@ -0,0 +1,127 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestLocalityOrder(t *testing.T) {
+	treeWithMissing, _ := GetBlockWithMultiIBTestSet()
+	treeWithMissing.Vertices[topology.TopologyTree].Vertices[topology.NoTopology] = &topology.Vertex{
+		ID:       topology.NoTopology,
+		Vertices: map[string]*topology.Vertex{"I99": {ID: "I99", Name: "Node999"}},
+	}
+
+	n1 := &topology.Vertex{ID: "I1", Name: "n1"}
+	n2 := &topology.Vertex{ID: "I2", Name: "n2"}
+	n3 := &topology.Vertex{ID: "I3", Name: "n3"}
+	n4 := &topology.Vertex{ID: "I4", Name: "n4"}
+	mixedBlocks := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{
+					"S1": {ID: "S1", Vertices: map[string]*topology.Vertex{"I1": n1, "I2": n2, "I3": n3, "I4": n4}},
+				},
+			},
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"Ba": {ID: "Ba", Vertices: map[string]*topology.Vertex{"I2": n2, "I4": n4}},
+					"Bb": {ID: "Bb", Vertices: map[string]*topology.Vertex{"I1": n1, "I3": n3}},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name  string
+		root  func() *topology.Vertex
+		count int
+		nodes []string
+		err   string
+	}{
+		{
+			name:  "Case 1: tree order",
+			root:  func() *topology.Vertex { v, _ := GetBlockWithMultiIBTestSet(); return v },
+			nodes: []string{"Node301", "Node302", "Node303", "Node401", "Node402", "Node403", "Node104", "Node105", "Node106", "Node201", "Node202", "Node205"},
+		},
+		{
+			name:  "Case 2: nodes without topology last",
+			root:  func() *topology.Vertex { return treeWithMissing },
+			nodes: []string{"Node301", "Node302", "Node303", "Node401", "Node402", "Node403", "Node104", "Node105", "Node106", "Node201", "Node202", "Node205", "Node999"},
+		},
+		{
+			name:  "Case 3: nodes grouped by block",
+			root:  func() *topology.Vertex { return mixedBlocks },
+			nodes: []string{"n1", "n3", "n2", "n4"},
+		},
+		{
+			name:  "Case 4: block only",
+			root:  func() *topology.Vertex { v, _ := getBlockTestSet(); return v },
+			nodes: []string{"Node104", "Node105", "Node106", "Node201", "Node202", "Node205"},
+		},
+		{
+			name:  "Case 5: subset within a leaf switch",
+			root:  func() *topology.Vertex { return treeWithMissing },
+			count: 2,
+			nodes: []string{"Node301", "Node302"},
+		},
+		{
+			name:  "Case 6: subset within a spine switch",
+			root:  func() *topology.Vertex { return treeWithMissing },
+			count: 5,
+			nodes: []string{"Node301", "Node302", "Node303", "Node401", "Node402"},
+		},
+		{
+			name:  "Case 7: subset across spine switches",
+			root:  func() *topology.Vertex { return treeWithMissing },
+			count: 7,
+			nodes: []string{"Node301", "Node302", "Node303", "Node401", "Node402", "Node403", "Node104"},
+		},
+		{
+			name:  "Case 8: subset with nodes without topology",
+			root:  func() *topology.Vertex { return treeWithMissing },
+			count: 13,
+			nodes: []string{"Node301", "Node302", "Node303", "Node401", "Node402", "Node403", "Node104", "Node105", "Node106", "Node201", "Node202", "Node205", "Node999"},
+		},
+		{
+			name:  "Case 9: subset of blocks",
+			root:  func() *topology.Vertex { v, _ := getBlockTestSet(); return v },
+			count: 4,
+			nodes: []string{"Node104", "Node105", "Node106", "Node201"},
+		},
+		{
+			name:  "Case 10: too many nodes",
+			root:  func() *topology.Vertex { return treeWithMissing },
+			count: 14,
+			err:   "requested 14 nodes, but the topology has 13 nodes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := tc.root()
+			cfg := &Config{Plugin: topology.TopologyTree}
+			if _, ok := root.Vertices[topology.TopologyTree]; !ok {
+				cfg.Plugin = topology.TopologyBlock
+			}
+			nt, err := NewNetworkTopology(root, cfg)
+			require.NoError(t, err)
+
+			nodes, err := nt.LocalityOrder(tc.count)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.nodes, nodes)
+			}
+		})
+	}
+}
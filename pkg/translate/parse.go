@@ -0,0 +1,463 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// Parse reads SLURM topology config in "topology/tree", "topology/block" or
+// multi-topology YAML format and returns the topology graph in the same form
+// the providers produce it.
+// Since the config carries no instance IDs, node vertices use the node name as the ID.
+func Parse(rd io.Reader) (*topology.Vertex, error) {
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topology config: %v", err)
+	}
+
+	if isYamlConfig(data) {
+		return parseYaml(data)
+	}
+
+	return parseConf(data)
+}
+
+// isYamlConfig returns true if the first meaningful line starts a YAML sequence
+func isYamlConfig(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return strings.HasPrefix(line, "-")
+	}
+	return false
+}
+
+// switchDef accumulates "SwitchName" lines for a single switch
+type switchDef struct {
+	name     string
+	id       string
+	switches []string
+	nodes    []string
+}
+
+// blockDef accumulates "BlockName" lines for a single block
+type blockDef struct {
+	id    string
+	name  string
+	nodes []string
+}
+
+func parseConf(data []byte) (*topology.Vertex, error) {
+	var (
+		switches   []*switchDef
+		switchMap  = make(map[string]*switchDef)
+		blocks     []*blockDef
+		blockMap   = make(map[string]*blockDef)
+		blockSizes string
+		headers    = make(map[string]string)
+		warnings   []topology.Warning
+		comment    [2]string // key and value of the preceding "# key=value" comment
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			text := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			if key, v, ok := cutHeader(text); ok {
+				switch {
+				case len(v) == 0:
+				case key == topology.KeyWarning:
+					// the warning kind is not serialized in the header
+					warnings = append(warnings, topology.Warning{Message: v})
+				default:
+					headers[key] = v
+				}
+			} else if key, val, ok := strings.Cut(text, "="); ok && !strings.ContainsAny(text, " \t") {
+				comment = [2]string{key, val}
+			}
+			continue
+		}
+
+		attrs, err := parseAttributes(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+
+		switch {
+		case len(attrs["SwitchName"]) != 0:
+			name := attrs["SwitchName"]
+			sw, ok := switchMap[name]
+			if !ok {
+				sw = &switchDef{name: name, id: name}
+				switchMap[name] = sw
+				switches = append(switches, sw)
+			}
+			// the generator emits "# <name>=<ID>" before switches with custom names
+			if comment[0] == name {
+				sw.id = comment[1]
+			}
+			if val, ok := attrs["Switches"]; ok {
+				sw.switches = append(sw.switches, cluset.ExpandList(val)...)
+			}
+			if val, ok := attrs["Nodes"]; ok {
+				nodes := cluset.ExpandList(val)
+				if node, ok := findDuplicate(nodes); ok {
+					return nil, fmt.Errorf("line %d: duplicate node %q", lineNum, node)
+				}
+				sw.nodes = append(sw.nodes, nodes...)
+			}
+
+		case len(attrs["BlockName"]) != 0:
+			id := attrs["BlockName"]
+			if _, ok := blockMap[id]; ok {
+				return nil, fmt.Errorf("line %d: duplicate block %q", lineNum, id)
+			}
+			block := &blockDef{id: id, nodes: cluset.ExpandList(attrs["Nodes"])}
+			// the generator emits "# <ID>=<name>" before named blocks
+			if comment[0] == id {
+				block.name = comment[1]
+			}
+			blockMap[id] = block
+			blocks = append(blocks, block)
+
+		case len(attrs["BlockSizes"]) != 0:
+			blockSizes = attrs["BlockSizes"]
+
+		default:
+			return nil, fmt.Errorf("line %d: unsupported topology config line %q", lineNum, line)
+		}
+		comment = [2]string{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan topology config: %v", err)
+	}
+
+	root := &topology.Vertex{
+		Vertices: make(map[string]*topology.Vertex),
+		Metadata: make(map[string]string),
+		Warnings: warnings,
+	}
+
+	if len(switches) != 0 {
+		treeRoot, err := buildTree(switches)
+		if err != nil {
+			return nil, err
+		}
+		root.Vertices[topology.TopologyTree] = treeRoot
+	}
+
+	if len(blocks) != 0 {
+		blockRoot := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		nodes := make(map[string]string)
+		for _, block := range blocks {
+			for _, node := range block.nodes {
+				if other, ok := nodes[node]; ok {
+					return nil, fmt.Errorf("node %q belongs to blocks %q and %q", node, other, block.id)
+				}
+				nodes[node] = block.id
+			}
+			blockRoot.Vertices[block.id] = newBlockVertex(block)
+		}
+		root.Vertices[topology.TopologyBlock] = blockRoot
+	}
+
+	if len(blockSizes) != 0 {
+		root.Metadata[topology.KeyBlockSizes] = blockSizes
+	}
+	maps.Copy(root.Metadata, headers)
+
+	return root, nil
+}
+
+// cutHeader returns the key and value of a header comment, such as "generated_at: <timestamp>"
+func cutHeader(text string) (string, string, bool) {
+	for _, key := range topology.HeaderKeys {
+		if v, ok := strings.CutPrefix(text, key+":"); ok {
+			return key, strings.TrimSpace(v), true
+		}
+	}
+	return "", "", false
+}
+
+// parseAttributes splits a config line into a map of "key=value" pairs
+func parseAttributes(line string) (map[string]string, error) {
+	attrs := make(map[string]string)
+	for _, field := range strings.Fields(line) {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid attribute %q", field)
+		}
+		attrs[key] = val
+	}
+	return attrs, nil
+}
+
+// buildTree links switch definitions into a tree and returns its root.
+// Switches referenced by no other switch become children of the root.
+func buildTree(switches []*switchDef) (*topology.Vertex, error) {
+	vertices := make(map[string]*topology.Vertex)
+	nodes := make(map[string]string)
+	for _, sw := range switches {
+		v := &topology.Vertex{
+			ID:       sw.id,
+			Vertices: make(map[string]*topology.Vertex),
+		}
+		if sw.id != sw.name {
+			v.Name = sw.name
+		}
+		for _, node := range sw.nodes {
+			if other, ok := nodes[node]; ok && other != sw.name {
+				return nil, fmt.Errorf("node %q is connected to switches %q and %q", node, other, sw.name)
+			}
+			nodes[node] = sw.name
+			v.Vertices[node] = &topology.Vertex{ID: node, Name: node}
+		}
+		vertices[sw.name] = v
+	}
+
+	children := make(map[string]bool)
+	for _, sw := range switches {
+		parent := vertices[sw.name]
+		for _, name := range sw.switches {
+			child, ok := vertices[name]
+			if !ok {
+				return nil, fmt.Errorf("switch %q refers to undefined switch %q", sw.name, name)
+			}
+			if name == sw.name {
+				return nil, fmt.Errorf("switch %q refers to itself", name)
+			}
+			parent.Vertices[child.ID] = child
+			children[name] = true
+		}
+	}
+
+	treeRoot := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+	for _, sw := range switches {
+		if !children[sw.name] {
+			v := vertices[sw.name]
+			treeRoot.Vertices[v.ID] = v
+		}
+	}
+
+	if len(treeRoot.Vertices) == 0 {
+		return nil, fmt.Errorf("switch hierarchy has no root")
+	}
+
+	if err := checkLoops(switches, children); err != nil {
+		return nil, err
+	}
+
+	return treeRoot, nil
+}
+
+// checkLoops walks the switch hierarchy from the roots and returns an error
+// if a switch is reached again on the same path or is not reachable from any root
+func checkLoops(switches []*switchDef, children map[string]bool) error {
+	switchMap := make(map[string]*switchDef)
+	for _, sw := range switches {
+		switchMap[sw.name] = sw
+	}
+
+	// visited is false while the switch is on the current path and true once its subtree is walked
+	visited := make(map[string]bool)
+	var walk func(sw *switchDef) error
+	walk = func(sw *switchDef) error {
+		visited[sw.name] = false
+		for _, name := range sw.switches {
+			done, ok := visited[name]
+			if !ok {
+				if err := walk(switchMap[name]); err != nil {
+					return err
+				}
+			} else if !done {
+				return fmt.Errorf("switch %q is in a loop", name)
+			}
+		}
+		visited[sw.name] = true
+		return nil
+	}
+
+	for _, sw := range switches {
+		if !children[sw.name] {
+			if err := walk(sw); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, sw := range switches {
+		if _, ok := visited[sw.name]; !ok {
+			return fmt.Errorf("switch %q is in a loop", sw.name)
+		}
+	}
+
+	return nil
+}
+
+// findDuplicate returns the first item listed more than once
+func findDuplicate(items []string) (string, bool) {
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if seen[item] {
+			return item, true
+		}
+		seen[item] = true
+	}
+	return "", false
+}
+
+func newBlockVertex(block *blockDef) *topology.Vertex {
+	v := &topology.Vertex{
+		ID:       block.id,
+		Name:     block.name,
+		Vertices: make(map[string]*topology.Vertex),
+	}
+	for _, node := range block.nodes {
+		v.Vertices[node] = &topology.Vertex{ID: node, Name: node}
+	}
+	return v
+}
+
+// parseYaml merges the per-partition topologies into a single graph.
+// Switch names are cluster-wide, so tree units merge by switch name.
+// Block names are local to a topology unit, so blocks sharing a node are merged
+// into the same physical block.
+func parseYaml(data []byte) (*topology.Vertex, error) {
+	var units []*TopologyUnit
+	if err := yaml.Unmarshal(data, &units); err != nil {
+		return nil, fmt.Errorf("failed to parse topology config: %v", err)
+	}
+
+	var (
+		switches  []*switchDef
+		switchMap = make(map[string]*switchDef)
+		blocks    []*blockDef
+		blockIDs  = make(map[string]bool)
+		nodeBlock = make(map[string]*blockDef)
+	)
+
+	for _, unit := range units {
+		if unit == nil {
+			continue
+		}
+		if len(unit.Name) == 0 {
+			return nil, fmt.Errorf("missing topology name")
+		}
+
+		if unit.Tree != nil {
+			for _, s := range unit.Tree.Switches {
+				if len(s.Name) == 0 {
+					return nil, fmt.Errorf("topology %q: missing switch name", unit.Name)
+				}
+				sw, ok := switchMap[s.Name]
+				if !ok {
+					sw = &switchDef{name: s.Name, id: s.Name}
+					switchMap[s.Name] = sw
+					switches = append(switches, sw)
+				}
+				sw.switches = appendUnique(sw.switches, cluset.ExpandList(s.Children))
+				sw.nodes = appendUnique(sw.nodes, cluset.ExpandList(s.Nodes))
+			}
+		}
+
+		if unit.Block != nil {
+			// blocks of the same topology unit must not be merged
+			claimed := make(map[*blockDef]bool)
+			for _, b := range unit.Block.Blocks {
+				if len(b.Name) == 0 {
+					return nil, fmt.Errorf("topology %q: missing block name", unit.Name)
+				}
+				nodes := cluset.ExpandList(b.Nodes)
+				var block *blockDef
+				for _, node := range nodes {
+					if block = nodeBlock[node]; block != nil {
+						if claimed[block] {
+							return nil, fmt.Errorf("node %q belongs to multiple blocks", node)
+						}
+						break
+					}
+				}
+				if block == nil {
+					id := b.Name
+					if blockIDs[id] {
+						id = unit.Name + "." + b.Name
+					}
+					if blockIDs[id] {
+						return nil, fmt.Errorf("topology %q: duplicate block %q", unit.Name, b.Name)
+					}
+					blockIDs[id] = true
+					block = &blockDef{id: id}
+					blocks = append(blocks, block)
+				}
+				claimed[block] = true
+				for _, node := range nodes {
+					if other, ok := nodeBlock[node]; ok && other != block {
+						return nil, fmt.Errorf("node %q belongs to multiple blocks", node)
+					}
+					if _, ok := nodeBlock[node]; !ok {
+						nodeBlock[node] = block
+						block.nodes = append(block.nodes, node)
+					}
+				}
+			}
+		}
+	}
+
+	root := &topology.Vertex{
+		Vertices: make(map[string]*topology.Vertex),
+	}
+
+	if len(switches) != 0 {
+		treeRoot, err := buildTree(switches)
+		if err != nil {
+			return nil, err
+		}
+		root.Vertices[topology.TopologyTree] = treeRoot
+	}
+
+	if len(blocks) != 0 {
+		blockRoot := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		for _, block := range blocks {
+			sort.Strings(block.nodes)
+			blockRoot.Vertices[block.id] = newBlockVertex(block)
+		}
+		root.Vertices[topology.TopologyBlock] = blockRoot
+	}
+
+	return root, nil
+}
+
+func appendUnique(list, items []string) []string {
+	seen := newSelector(list)
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			list = append(list, item)
+		}
+	}
+	return list
+}
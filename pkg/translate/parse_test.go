@@ -0,0 +1,276 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestParseTree(t *testing.T) {
+	root, err := Parse(strings.NewReader(testTreeConfig))
+	require.NoError(t, err)
+
+	n21 := &topology.Vertex{ID: "Node201", Name: "Node201"}
+	n22 := &topology.Vertex{ID: "Node202", Name: "Node202"}
+	n25 := &topology.Vertex{ID: "Node205", Name: "Node205"}
+	n34 := &topology.Vertex{ID: "Node304", Name: "Node304"}
+	n35 := &topology.Vertex{ID: "Node305", Name: "Node305"}
+	n36 := &topology.Vertex{ID: "Node306", Name: "Node306"}
+
+	sw2 := &topology.Vertex{
+		ID:       "S2",
+		Vertices: map[string]*topology.Vertex{"Node201": n21, "Node202": n22, "Node205": n25},
+	}
+	sw3 := &topology.Vertex{
+		ID:       "S3",
+		Vertices: map[string]*topology.Vertex{"Node304": n34, "Node305": n35, "Node306": n36},
+	}
+	sw1 := &topology.Vertex{
+		ID:       "S1",
+		Vertices: map[string]*topology.Vertex{"S2": sw2, "S3": sw3},
+	}
+	expected := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyTree: {
+				Vertices: map[string]*topology.Vertex{"S1": sw1},
+			},
+		},
+		Metadata: map[string]string{},
+	}
+
+	require.Equal(t, expected, root)
+}
+
+func TestParseBlock(t *testing.T) {
+	config := `# generated_at: 2026-01-02T03:04:05Z
+# provider: oci-imds
+# warning: region us-east-1 failed: API error
+# B1=nvl-domain-1
+BlockName=B1 Nodes=Node[104-106]
+BlockName=B2 Nodes=Node[201-202,205]
+BlockSizes=3,6
+`
+	root, err := Parse(strings.NewReader(config))
+	require.NoError(t, err)
+
+	expected := &topology.Vertex{
+		Vertices: map[string]*topology.Vertex{
+			topology.TopologyBlock: {
+				Vertices: map[string]*topology.Vertex{
+					"B1": {
+						ID:   "B1",
+						Name: "nvl-domain-1",
+						Vertices: map[string]*topology.Vertex{
+							"Node104": {ID: "Node104", Name: "Node104"},
+							"Node105": {ID: "Node105", Name: "Node105"},
+							"Node106": {ID: "Node106", Name: "Node106"},
+						},
+					},
+					"B2": {
+						ID: "B2",
+						Vertices: map[string]*topology.Vertex{
+							"Node201": {ID: "Node201", Name: "Node201"},
+							"Node202": {ID: "Node202", Name: "Node202"},
+							"Node205": {ID: "Node205", Name: "Node205"},
+						},
+					},
+				},
+			},
+		},
+		Metadata: map[string]string{
+			topology.KeyBlockSizes:  "3,6",
+			topology.KeyGeneratedAt: "2026-01-02T03:04:05Z",
+			topology.KeyProvider:    "oci-imds",
+		},
+		Warnings: []topology.Warning{{Message: "region us-east-1 failed: API error"}},
+	}
+
+	require.Equal(t, expected, root)
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "Case 1: invalid attribute",
+			config: "SwitchName=S1 Nodes",
+			err:    `line 1: invalid attribute "Nodes"`,
+		},
+		{
+			name:   "Case 2: unsupported line",
+			config: "\nPartitionName=p1 Nodes=n1\n",
+			err:    `line 2: unsupported topology config line "PartitionName=p1 Nodes=n1"`,
+		},
+		{
+			name:   "Case 3: undefined switch",
+			config: "SwitchName=S1 Switches=S2\n",
+			err:    `switch "S1" refers to undefined switch "S2"`,
+		},
+		{
+			name:   "Case 4: node with two parents",
+			config: "SwitchName=S1 Nodes=n[1-2]\nSwitchName=S2 Nodes=n2\n",
+			err:    `node "n2" is connected to switches "S1" and "S2"`,
+		},
+		{
+			name:   "Case 5: switch loop",
+			config: "SwitchName=S1 Switches=S2\nSwitchName=S2 Switches=S1\n",
+			err:    "switch hierarchy has no root",
+		},
+		{
+			name:   "Case 6: duplicate block",
+			config: "BlockName=B1 Nodes=n1\nBlockName=B1 Nodes=n2\n",
+			err:    `line 2: duplicate block "B1"`,
+		},
+		{
+			name:   "Case 7: node in multiple blocks",
+			config: "BlockName=B1 Nodes=n[1-2]\nBlockName=B2 Nodes=n[2-3]\n",
+			err:    `node "n2" belongs to blocks "B1" and "B2"`,
+		},
+		{
+			name:   "Case 8: invalid yaml",
+			config: "- topology: [\n",
+			err:    "failed to parse topology config: yaml: line 1: did not find expected node content",
+		},
+		{
+			name:   "Case 9: node in multiple yaml blocks",
+			config: "- topology: t1\n  block:\n    blocks:\n      - block: b0\n        nodes: n[1-2]\n      - block: b1\n        nodes: n[2-3]\n",
+			err:    `node "n2" belongs to multiple blocks`,
+		},
+		{
+			name:   "Case 10: switch loop next to a root",
+			config: "SwitchName=S1 Switches=S2\nSwitchName=S2 Switches=S1\nSwitchName=S3 Nodes=n1\n",
+			err:    `switch "S1" is in a loop`,
+		},
+		{
+			name:   "Case 11: switch loop below a root",
+			config: "SwitchName=S1 Switches=S2\nSwitchName=S2 Switches=S3\nSwitchName=S3 Switches=S2\n",
+			err:    `switch "S2" is in a loop`,
+		},
+		{
+			name:   "Case 12: duplicate node on a switch line",
+			config: "SwitchName=S1 Nodes=n[1-2],n2\n",
+			err:    `line 1: duplicate node "n2"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tc.config))
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	treeRoot, _ := GetTreeTestSet(false)
+	blockRoot, _ := getBlockTestSet()
+	multiRoot, _ := GetBlockWithMultiIBTestSet()
+	blockOnlyRoot, _ := GetBlockWithMultiIBTestSet()
+	delete(blockOnlyRoot.Vertices, topology.TopologyTree)
+	headerRoot, _ := getBlockTestSet()
+	headerRoot.Metadata = map[string]string{
+		topology.KeyGeneratedAt: "2026-01-02T03:04:05Z",
+		topology.KeyProvider:    "oci-imds",
+	}
+	headerRoot.Warnings = []topology.Warning{
+		topology.MissingTopologyWarning("zone", "Node104", "PhysicalHostTopology"),
+	}
+
+	testCases := []struct {
+		name string
+		root *topology.Vertex
+		cfg  *Config
+	}{
+		{
+			name: "Case 1: tree",
+			root: treeRoot,
+			cfg:  &Config{Plugin: topology.TopologyTree},
+		},
+		{
+			name: "Case 2: tree with multiple roots",
+			root: multiRoot,
+			cfg:  &Config{Plugin: topology.TopologyTree},
+		},
+		{
+			name: "Case 3: block",
+			root: blockRoot,
+			cfg:  &Config{Plugin: topology.TopologyBlock, BlockSizes: []int{3}},
+		},
+		{
+			name: "Case 4: tree yaml",
+			root: treeRoot,
+			cfg: &Config{
+				Topologies: map[string]*TopologySpec{
+					"topo1": {Plugin: topology.TopologyTree, Nodes: []string{"Node[201,205]"}},
+					"topo2": {Plugin: topology.TopologyTree, Nodes: []string{"Node[201-202,304-305]"}, ClusterDefault: true},
+				},
+			},
+		},
+		{
+			name: "Case 5: block yaml",
+			root: blockOnlyRoot,
+			cfg: &Config{
+				Topologies: map[string]*TopologySpec{
+					"topo1": {Plugin: topology.TopologyBlock, Nodes: []string{"Node[104,105]"}, ClusterDefault: true},
+					"topo2": {Plugin: topology.TopologyBlock, Nodes: []string{"Node[301,303]"}},
+					"topo3": {Plugin: topology.TopologyFlat},
+				},
+			},
+		},
+		{
+			name: "Case 6: block with headers",
+			root: headerRoot,
+			cfg:  &Config{Plugin: topology.TopologyBlock, BlockSizes: []int{3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nt, err := NewNetworkTopology(tc.root, tc.cfg)
+			require.NoError(t, err)
+			buf := &bytes.Buffer{}
+			require.Nil(t, nt.Generate(buf))
+
+			parsed, err := Parse(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+
+			nt, err = NewNetworkTopology(parsed, tc.cfg)
+			require.NoError(t, err)
+			out := &bytes.Buffer{}
+			require.Nil(t, nt.Generate(out))
+			require.Equal(t, buf.String(), out.String())
+		})
+	}
+}
+
+func TestParseNamedSwitches(t *testing.T) {
+	root, err := Parse(strings.NewReader(shortNameExpectedResult))
+	require.NoError(t, err)
+
+	tree := root.Vertices[topology.TopologyTree]
+	require.NotNil(t, tree)
+	top, ok := tree.Vertices["hpcislandid-1"]
+	require.True(t, ok)
+	require.Equal(t, "switch.3.1", top.Name)
+	leaf := top.Vertices["network-block-2"].Vertices["local-block-2"]
+	require.Equal(t, "switch.1.2", leaf.Name)
+	require.Contains(t, leaf.Vertices, "node-2")
+
+	nt, err := NewNetworkTopology(root, &Config{Plugin: topology.TopologyTree})
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.Nil(t, nt.Generate(buf))
+	require.Equal(t, shortNameExpectedResult, buf.String())
+}
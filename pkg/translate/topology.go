@@ -39,7 +39,7 @@ type NetworkTopology struct {
 	blocks   []*blockInfo                // blocks
 	vertices map[string]*topology.Vertex // object ID to Vertex map
 	nodeInfo map[string]*nodeInfo        // node name to nodeInfo map
-	metadata map[string]string           // root vertex metadata propagated to output
+	header   [][2]string                 // root vertex metadata and warnings propagated to output
 }
 
 type blockInfo struct {
@@ -106,7 +106,7 @@ func NewNetworkTopology(root *topology.Vertex, cfg *Config) (*NetworkTopology, e
 		tree:     make(map[string][]string),
 		vertices: make(map[string]*topology.Vertex),
 		nodeInfo: make(map[string]*nodeInfo),
-		metadata: root.Metadata,
+		header:   root.Header(),
 	}
 
 	nt.initTree(root)
@@ -262,11 +262,11 @@ func (nt *NetworkTopology) Generate(wr io.Writer) *httperr.Error {
 	}
 }
 
-// writeHeader emits comment lines for metadata propagated from the root vertex
+// writeHeader emits comment lines for metadata and warnings propagated from the root vertex
 // (e.g. provider-supplied generation timestamps).
 func (nt *NetworkTopology) writeHeader(wr io.Writer) error {
-	if v, ok := nt.metadata[topology.KeyGeneratedAt]; ok && len(v) != 0 {
-		if _, err := fmt.Fprintf(wr, "# generated_at: %s\n", v); err != nil {
+	for _, kv := range nt.header {
+		if _, err := fmt.Fprintf(wr, "# %s: %s\n", kv[0], kv[1]); err != nil {
 			return err
 		}
 	}